@@ -0,0 +1,281 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// adminJob là biểu diễn JSON của JobStatus trong admin API.
+type adminJob struct {
+	Name      string     `json:"name"`
+	Tags      []string   `json:"tags"`
+	Schedule  string     `json:"schedule,omitempty"`
+	NextRun   *time.Time `json:"next_run,omitempty"`
+	LastRun   *time.Time `json:"last_run,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+	Running   bool       `json:"running"`
+	Paused    bool       `json:"paused"`
+}
+
+// adminRun là biểu diễn JSON của RunResult trong admin API.
+type adminRun struct {
+	Name    string `json:"name"`
+	Error   string `json:"error,omitempty"`
+	Skipped bool   `json:"skipped"`
+}
+
+// adminScheduler là biểu diễn JSON của trạng thái scheduler trong admin API.
+type adminScheduler struct {
+	Running bool `json:"running"`
+	Jobs    int  `json:"jobs"`
+}
+
+// adminHandler phục vụ admin API cho một Manager.
+type adminHandler struct {
+	manager Manager
+	stopMu  sync.Mutex // Tuần tự hóa các lần dừng scheduler ở nền
+}
+
+// NewAdminHandler tạo http.Handler cung cấp admin API dạng JSON để xem và điều khiển
+// các job của manager. Các route (tương đối với nơi handler được mount):
+//
+//	GET    /jobs                     danh sách job, lọc theo tag với ?tag=
+//	GET    /jobs/{name}              trạng thái của job
+//	DELETE /jobs/{name}              xóa job
+//	POST   /jobs/{name}/run          chạy ngay job
+//	POST   /jobs/{name}/pause        tạm dừng job
+//	POST   /jobs/{name}/resume       tiếp tục job
+//	POST   /jobs/{name}/reschedule   đổi lịch chạy của job, body {"cron"|"interval"|"at": "..."}
+//	DELETE /tags/{tag}               xóa các job có tag
+//	POST   /tags/{tag}/run           chạy ngay các job có tag
+//	POST   /tags/{tag}/pause         tạm dừng các job có tag
+//	POST   /tags/{tag}/resume        tiếp tục các job có tag
+//	GET    /pauses                   các job và tag đang bị tạm dừng
+//	GET    /scheduler                trạng thái scheduler
+//	POST   /scheduler/start          khởi động scheduler
+//	POST   /scheduler/stop           dừng scheduler, hủy context của các job đang chạy
+//
+// Lịch chạy không hợp lệ trả về status 400. Các route run trả về 202 ngay khi lần chạy được
+// kích hoạt; với ?wait=true, route chờ các lần chạy kết thúc và trả về 200 cùng kết quả dạng
+// {"runs": [{"name", "error", "skipped"}]}. Tương tự, /scheduler/stop trả về 202 ngay và dừng
+// scheduler ở nền; với ?wait=true, route chờ scheduler dừng và trả về 200 cùng trạng thái
+// scheduler. Khi chờ, request bị hủy không dừng việc chạy hoặc dừng đang diễn ra. Tên job chứa
+// "/" phải được escape thành "%2F". Lỗi được trả về dạng {"error": "..."}, với status 404 khi
+// không tìm thấy job. Handler không xác thực request; ứng dụng cần bọc handler bằng middleware
+// xác thực trước khi mount.
+//
+// Example:
+//
+//	mux.Handle("/admin/scheduler/", http.StripPrefix("/admin/scheduler", scheduler.NewAdminHandler(sched)))
+func NewAdminHandler(manager Manager) http.Handler {
+	h := &adminHandler{manager: manager}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /jobs", h.listJobs)
+	mux.HandleFunc("GET /jobs/{name}", h.getJob)
+	mux.HandleFunc("DELETE /jobs/{name}", h.action("name", http.StatusOK, manager.RemoveByName))
+	mux.HandleFunc("POST /jobs/{name}/run", h.run("name", manager.RunNow))
+	mux.HandleFunc("POST /jobs/{name}/pause", h.action("name", http.StatusOK, manager.Pause))
+	mux.HandleFunc("POST /jobs/{name}/resume", h.action("name", http.StatusOK, manager.Resume))
+	mux.HandleFunc("POST /jobs/{name}/reschedule", h.reschedule)
+	mux.HandleFunc("DELETE /tags/{tag}", h.action("tag", http.StatusOK, manager.RemoveByTag))
+	mux.HandleFunc("POST /tags/{tag}/run", h.run("tag", manager.RunByTag))
+	mux.HandleFunc("POST /tags/{tag}/pause", h.action("tag", http.StatusOK, manager.PauseByTag))
+	mux.HandleFunc("POST /tags/{tag}/resume", h.action("tag", http.StatusOK, manager.ResumeByTag))
+	mux.HandleFunc("GET /pauses", h.getPauses)
+	mux.HandleFunc("GET /scheduler", h.getScheduler)
+	mux.HandleFunc("POST /scheduler/start", h.startScheduler)
+	mux.HandleFunc("POST /scheduler/stop", h.stopScheduler)
+	return mux
+}
+
+// listJobs trả về danh sách job, lọc theo query tag nếu có.
+func (h *adminHandler) listJobs(w http.ResponseWriter, r *http.Request) {
+	tag := r.URL.Query().Get("tag")
+
+	jobs := []adminJob{}
+	for _, status := range h.manager.Jobs() {
+		if tag != "" && !slices.Contains(status.Tags, tag) {
+			continue
+		}
+		jobs = append(jobs, toAdminJob(status))
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"jobs": jobs})
+}
+
+// getJob trả về trạng thái của job theo tên.
+func (h *adminHandler) getJob(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	for _, status := range h.manager.Jobs() {
+		if status.Name == name {
+			writeJSON(w, http.StatusOK, toAdminJob(status))
+			return
+		}
+	}
+	writeError(w, fmt.Errorf("%w: %s", ErrJobNotFound, name))
+}
+
+// action tạo handler gọi fn với giá trị của path wildcard key, trả về status nếu thành công.
+func (h *adminHandler) action(key string, status int, fn func(string) error) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(r.PathValue(key)); err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, status, map[string]string{"status": "ok"})
+	}
+}
+
+// run tạo handler kích hoạt lần chạy qua fn với giá trị của path wildcard key. Với query
+// wait=true, handler chờ các lần chạy kết thúc (hoặc request bị hủy) và trả về kết quả.
+func (h *adminHandler) run(key string, fn func(string) (*RunHandle, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handle, err := fn(r.PathValue(key))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		if r.URL.Query().Get("wait") != "true" {
+			writeJSON(w, http.StatusAccepted, map[string]string{"status": "ok"})
+			return
+		}
+
+		// Lỗi của từng lần chạy được trả về trong kết quả, chỉ lỗi của ctx được xử lý ở đây
+		if err := handle.Wait(r.Context()); err != nil && handle.Results() == nil {
+			writeError(w, err)
+			return
+		}
+		runs := []adminRun{}
+		for _, result := range handle.Results() {
+			run := adminRun{Name: result.Name, Skipped: result.Skipped}
+			if result.Err != nil {
+				run.Error = result.Err.Error()
+			}
+			runs = append(runs, run)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"runs": runs})
+	}
+}
+
+// reschedule thay lịch chạy của job theo JobSchedule trong body của request.
+func (h *adminHandler) reschedule(w http.ResponseWriter, r *http.Request) {
+	var schedule JobSchedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body: " + err.Error()})
+		return
+	}
+	if err := h.manager.Reschedule(r.PathValue("name"), schedule); err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// getPauses trả về các job và tag đang bị tạm dừng.
+func (h *adminHandler) getPauses(w http.ResponseWriter, r *http.Request) {
+	state, err := h.manager.PauseState(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if state.Jobs == nil {
+		state.Jobs = []string{}
+	}
+	if state.Tags == nil {
+		state.Tags = []string{}
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+// getScheduler trả về trạng thái của scheduler.
+func (h *adminHandler) getScheduler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, adminScheduler{
+		Running: h.manager.IsRunning(),
+		Jobs:    len(h.manager.Jobs()),
+	})
+}
+
+// startScheduler khởi động scheduler nếu chưa chạy.
+func (h *adminHandler) startScheduler(w http.ResponseWriter, r *http.Request) {
+	if !h.manager.IsRunning() {
+		h.manager.StartAsync()
+	}
+	h.getScheduler(w, r)
+}
+
+// stopScheduler dừng scheduler nếu đang chạy. Stop hủy context của các job đang chạy rồi chờ
+// chúng trả về nên được gọi ở nền; với query wait=true, handler chờ scheduler dừng (hoặc
+// request bị hủy).
+func (h *adminHandler) stopScheduler(w http.ResponseWriter, r *http.Request) {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		h.stopMu.Lock()
+		defer h.stopMu.Unlock()
+		if h.manager.IsRunning() {
+			h.manager.Stop()
+		}
+	}()
+
+	if r.URL.Query().Get("wait") != "true" {
+		writeJSON(w, http.StatusAccepted, map[string]string{"status": "ok"})
+		return
+	}
+	select {
+	case <-stopped:
+		h.getScheduler(w, r)
+	case <-r.Context().Done():
+		writeError(w, r.Context().Err())
+	}
+}
+
+// toAdminJob chuyển JobStatus sang biểu diễn JSON.
+func toAdminJob(status JobStatus) adminJob {
+	job := adminJob{
+		Name:     status.Name,
+		Tags:     status.Tags,
+		Schedule: status.Schedule,
+		Running:  status.Running,
+		Paused:   status.Paused,
+	}
+	if job.Tags == nil {
+		job.Tags = []string{}
+	}
+	if !status.NextRun.IsZero() {
+		job.NextRun = &status.NextRun
+	}
+	if !status.LastRun.IsZero() {
+		job.LastRun = &status.LastRun
+	}
+	if status.LastError != nil {
+		job.LastError = status.LastError.Error()
+	}
+	return job
+}
+
+// writeError ghi lỗi dạng JSON, với status 404 khi không tìm thấy job và 400 khi lịch chạy
+// không hợp lệ.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrJobNotFound), errors.Is(err, gocron.ErrJobNotFoundWithTag):
+		status = http.StatusNotFound
+	case errors.Is(err, ErrInvalidJobSchedule), errors.Is(err, ErrInvalidJobInterval), errors.Is(err, ErrInvalidSchedule):
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeJSON ghi value dạng JSON với status.
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
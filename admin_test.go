@@ -0,0 +1,267 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// doAdminRequest gửi request tới admin handler và giải mã response JSON vào out (nếu có).
+func doAdminRequest(t *testing.T, handler http.Handler, method, path string, out interface{}) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("Failed to decode response %q: %v", rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestAdminHandlerListsJobs(t *testing.T) {
+	scheduler := NewScheduler()
+	handler := NewAdminHandler(scheduler)
+
+	if _, err := scheduler.Every(1).Hours().Name("sync").Tag("billing").DoContext(func(ctx context.Context) error {
+		return errors.New("upstream unavailable")
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if _, err := scheduler.Cron("0 3 * * *").Name("cleanup").DoContext(func(ctx context.Context) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	var list struct {
+		Jobs []map[string]interface{} `json:"jobs"`
+	}
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodGet, "/jobs", &list))
+	if len(list.Jobs) != 2 {
+		t.Fatalf("Expected 2 jobs, got %d", len(list.Jobs))
+	}
+	assert.Equal(t, map[string]interface{}{
+		"name":     "cleanup",
+		"tags":     []interface{}{},
+		"schedule": "cron 0 3 * * *",
+		"running":  false,
+		"paused":   false,
+	}, list.Jobs[0])
+
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodGet, "/jobs?tag=billing", &list))
+	if len(list.Jobs) != 1 {
+		t.Fatalf("Expected 1 job with tag billing, got %d", len(list.Jobs))
+	}
+	assert.Equal(t, "sync", list.Jobs[0]["name"])
+
+	// Lần chạy thất bại được phản ánh qua last_run và last_error
+	handle, err := scheduler.RunNow("sync")
+	if err != nil {
+		t.Fatalf("Failed to run job: %v", err)
+	}
+	_ = handle.Wait(context.Background())
+
+	var job adminJob
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodGet, "/jobs/sync", &job))
+	assert.Equal(t, "sync", job.Name)
+	assert.Equal(t, []string{"billing"}, job.Tags)
+	assert.Equal(t, "upstream unavailable", job.LastError)
+	assert.NotNil(t, job.LastRun)
+	assert.Nil(t, job.NextRun, "next run is unknown until the scheduler starts")
+
+	var failure map[string]string
+	assert.Equal(t, http.StatusNotFound, doAdminRequest(t, handler, http.MethodGet, "/jobs/missing", &failure))
+	assert.Contains(t, failure["error"], ErrJobNotFound.Error())
+}
+
+func TestAdminHandlerControlsJobs(t *testing.T) {
+	scheduler := NewScheduler()
+	defer scheduler.Stop()
+	handler := NewAdminHandler(scheduler)
+
+	var calls atomic.Int32
+	for _, name := range []string{"invoice", "charge"} {
+		if _, err := scheduler.Every(1).Hours().Name(name).Tag("billing").DoContext(func(ctx context.Context) error {
+			calls.Add(1)
+			return nil
+		}); err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+	}
+
+	assert.Equal(t, http.StatusAccepted, doAdminRequest(t, handler, http.MethodPost, "/jobs/invoice/run", nil))
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, http.StatusAccepted, doAdminRequest(t, handler, http.MethodPost, "/tags/billing/run", nil))
+	assert.Eventually(t, func() bool { return calls.Load() == 3 }, time.Second, 5*time.Millisecond)
+
+	var runs struct {
+		Runs []map[string]interface{} `json:"runs"`
+	}
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodPost, "/jobs/charge/run?wait=true", &runs))
+	assert.Equal(t, []map[string]interface{}{{"name": "charge", "skipped": false}}, runs.Runs)
+	assert.Equal(t, int32(4), calls.Load())
+
+	var job adminJob
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodPost, "/jobs/invoice/pause", nil))
+	doAdminRequest(t, handler, http.MethodGet, "/jobs/invoice", &job)
+	assert.True(t, job.Paused)
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodPost, "/jobs/invoice/resume", nil))
+	doAdminRequest(t, handler, http.MethodGet, "/jobs/invoice", &job)
+	assert.False(t, job.Paused)
+
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodPost, "/tags/billing/pause", nil))
+	doAdminRequest(t, handler, http.MethodGet, "/jobs/charge", &job)
+	assert.True(t, job.Paused)
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodPost, "/tags/billing/resume", nil))
+	doAdminRequest(t, handler, http.MethodGet, "/jobs/charge", &job)
+	assert.False(t, job.Paused)
+
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodDelete, "/jobs/invoice", nil))
+	assert.Equal(t, http.StatusNotFound, doAdminRequest(t, handler, http.MethodPost, "/jobs/invoice/run", nil))
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodDelete, "/tags/billing", nil))
+	assert.Equal(t, http.StatusNotFound, doAdminRequest(t, handler, http.MethodDelete, "/tags/billing", nil))
+	assert.Empty(t, scheduler.Jobs())
+}
+
+func TestAdminHandlerEscapedJobName(t *testing.T) {
+	scheduler := NewScheduler()
+	handler := NewAdminHandler(scheduler)
+
+	if _, err := scheduler.Every(1).Hours().Name("reports/daily").DoContext(func(ctx context.Context) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	var job adminJob
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodGet, "/jobs/reports%2Fdaily", &job))
+	assert.Equal(t, "reports/daily", job.Name)
+}
+
+func TestAdminHandlerStartsAndStopsScheduler(t *testing.T) {
+	scheduler := NewScheduler()
+	defer scheduler.Stop()
+	handler := NewAdminHandler(scheduler)
+
+	var state adminScheduler
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodGet, "/scheduler", &state))
+	assert.False(t, state.Running)
+
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodPost, "/scheduler/start", &state))
+	assert.True(t, state.Running)
+	assert.True(t, scheduler.IsRunning())
+
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodPost, "/scheduler/stop?wait=true", &state))
+	assert.False(t, state.Running)
+	assert.False(t, scheduler.IsRunning())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/scheduler/start", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestAdminHandlerStopsSchedulerInBackground(t *testing.T) {
+	scheduler := NewScheduler()
+	handler := NewAdminHandler(scheduler)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	if _, err := scheduler.Every(1).Hours().Name("slow").Do(func() {
+		close(started)
+		<-release // Job bỏ qua việc scheduler dừng
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	scheduler.StartAsync()
+	<-started
+
+	// Request không chờ job đang chạy kết thúc
+	assert.Equal(t, http.StatusAccepted, doAdminRequest(t, handler, http.MethodPost, "/scheduler/stop", nil))
+
+	// Request chờ scheduler dừng được trả về khi request bị hủy
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/scheduler/stop?wait=true", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	close(release)
+	var state adminScheduler
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodPost, "/scheduler/stop?wait=true", &state))
+	assert.False(t, state.Running)
+	assert.False(t, scheduler.IsRunning())
+}
+
+func TestAdminHandlerListsPauses(t *testing.T) {
+	scheduler := NewScheduler()
+	handler := NewAdminHandler(scheduler)
+
+	var state PauseState
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodGet, "/pauses", &state))
+	assert.Equal(t, PauseState{Jobs: []string{}, Tags: []string{}}, state)
+
+	if _, err := scheduler.Every(1).Hours().Name("invoice").Tag("billing").DoContext(func(ctx context.Context) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if err := scheduler.Pause("invoice"); err != nil {
+		t.Fatalf("Failed to pause job: %v", err)
+	}
+	if err := scheduler.PauseByTag("billing"); err != nil {
+		t.Fatalf("Failed to pause tag: %v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, doAdminRequest(t, handler, http.MethodGet, "/pauses", &state))
+	assert.Equal(t, PauseState{Jobs: []string{"invoice"}, Tags: []string{"billing"}}, state)
+}
+
+func TestAdminHandlerReschedulesJob(t *testing.T) {
+	scheduler := NewScheduler()
+	handler := NewAdminHandler(scheduler)
+
+	if _, err := scheduler.Every(1).Hours().Name("sync").DoContext(func(ctx context.Context) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	reschedule := func(path, body string) (int, map[string]string) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+		var response map[string]string
+		if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response %q: %v", rec.Body.String(), err)
+		}
+		return rec.Code, response
+	}
+
+	status, _ := reschedule("/jobs/sync/reschedule", `{"cron": "*/10 * * * *"}`)
+	assert.Equal(t, http.StatusOK, status)
+	var job adminJob
+	doAdminRequest(t, handler, http.MethodGet, "/jobs/sync", &job)
+	assert.Equal(t, "cron */10 * * * *", job.Schedule)
+
+	status, response := reschedule("/jobs/sync/reschedule", `{"cron": "*/10 * * * *", "interval": "5m"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, ErrInvalidJobSchedule.Error(), response["error"])
+
+	status, _ = reschedule("/jobs/sync/reschedule", `{"cron": "often"}`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = reschedule("/jobs/sync/reschedule", `not json`)
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	status, _ = reschedule("/jobs/missing/reschedule", `{"interval": "5m"}`)
+	assert.Equal(t, http.StatusNotFound, status)
+}
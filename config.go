@@ -1,6 +1,63 @@
 package scheduler
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// Các giá trị hợp lệ cho Config.LimitMode.
+const (
+	// LimitModeReschedule bỏ qua lần chạy khi đã đạt giới hạn MaxConcurrentJobs,
+	// job sẽ chạy lại ở lần lên lịch kế tiếp.
+	LimitModeReschedule = "reschedule"
+
+	// LimitModeWait đưa job vào hàng đợi khi đã đạt giới hạn MaxConcurrentJobs,
+	// job sẽ chạy ngay khi có slot trống.
+	LimitModeWait = "wait"
+)
+
+// Các giá trị hợp lệ cho DistributedLockConfig.Driver.
+const (
+	// LockDriverRedis sử dụng Redis Locker (mặc định).
+	LockDriverRedis = "redis"
+
+	// LockDriverPostgres sử dụng advisory lock của PostgreSQL.
+	LockDriverPostgres = "postgres"
+
+	// LockDriverMySQL sử dụng lease table trên MySQL.
+	LockDriverMySQL = "mysql"
+
+	// LockDriverSQLite sử dụng lease table trên SQLite.
+	LockDriverSQLite = "sqlite"
+)
+
+// Các locker backend có sẵn cho DistributedLockConfig.Backend, ngoài các giá trị của
+// DistributedLockConfig.Driver.
+const (
+	// LockBackendMemory sử dụng Memory Locker, chỉ phù hợp khi chạy một process.
+	LockBackendMemory = "memory"
+)
+
+// Các giá trị hợp lệ cho DistributedLockConfig.Mode.
+const (
+	// LockModePerJob lấy khóa phân tán cho mỗi lần chạy job (mặc định).
+	LockModePerJob = "per_job_lock"
+
+	// LockModeLeaderElection bầu chọn một leader, chỉ leader mới chạy job.
+	LockModeLeaderElection = "leader_election"
+)
+
+// Các giá trị hợp lệ cho DistributedLockConfig.RedisClient.
+const (
+	// RedisClientDefault sử dụng redis.Manager.Client() (single-node *redis.Client).
+	RedisClientDefault = "default"
+
+	// RedisClientUniversal sử dụng redis.Manager.UniversalClient(), hỗ trợ Cluster, Sentinel và Ring.
+	RedisClientUniversal = "universal"
+)
 
 // Config là cấu trúc cấu hình chính cho scheduler provider.
 //
@@ -11,11 +68,234 @@ type Config struct {
 	// ServiceProvider sẽ tự động gọi scheduler.StartAsync() trong Boot() method nếu true
 	AutoStart bool `mapstructure:"auto_start" yaml:"auto_start"`
 
+	// Timezone là tên múi giờ IANA (ví dụ "UTC", "Asia/Ho_Chi_Minh") dùng để tính lịch chạy của các job
+	// Để trống sẽ sử dụng time.Local
+	Timezone string `mapstructure:"timezone" yaml:"timezone"`
+
+	// Location là múi giờ được thiết lập trực tiếp từ code, được ưu tiên hơn Timezone
+	// Trường này không được load từ file config
+	Location *time.Location `mapstructure:"-" yaml:"-"`
+
+	// MaxConcurrentJobs giới hạn số job được chạy đồng thời, 0 nghĩa là không giới hạn
+	MaxConcurrentJobs int `mapstructure:"max_concurrent_jobs" yaml:"max_concurrent_jobs"`
+
+	// LimitMode xác định hành vi khi đạt giới hạn MaxConcurrentJobs: "reschedule" hoặc "wait"
+	LimitMode string `mapstructure:"limit_mode" yaml:"limit_mode"`
+
+	// TagsUnique bắt buộc mỗi tag chỉ được gán cho một job trong scheduler
+	TagsUnique bool `mapstructure:"tags_unique" yaml:"tags_unique"`
+
+	// WaitForSchedule khiến các job mới chờ đến lịch chạy đầu tiên thay vì chạy ngay khi scheduler start
+	WaitForSchedule bool `mapstructure:"wait_for_schedule" yaml:"wait_for_schedule"`
+
+	// LogLevel là level tối thiểu của các bản ghi log: "debug", "info" (mặc định), "warn" hoặc "error"
+	LogLevel string `mapstructure:"log_level" yaml:"log_level"`
+
+	// Logger là key trong DI container của *slog.Logger dùng để ghi log
+	// Để trống sẽ sử dụng slog.Default()
+	Logger string `mapstructure:"logger" yaml:"logger"`
+
+	// DefaultTimeout là thời gian chạy tối đa (giây) của job không đặt Timeout riêng
+	// 0 nghĩa là không giới hạn
+	DefaultTimeout int `mapstructure:"default_timeout" yaml:"default_timeout"`
+
+	// ShutdownTimeout là thời gian tối đa (giây) chờ các job đang chạy kết thúc khi ứng dụng dừng
+	// 0 nghĩa là chờ cho tới khi tất cả các job kết thúc
+	ShutdownTimeout int `mapstructure:"shutdown_timeout" yaml:"shutdown_timeout"`
+
 	// DistributedLock chứa cấu hình cho distributed locking
 	DistributedLock DistributedLockConfig `mapstructure:"distributed_lock" yaml:"distributed_lock"`
 
 	// Options chứa cấu hình RedisLockerOptions cho distributed locking
 	Options RedisLockerOptions `mapstructure:"options" yaml:"options"`
+
+	// Jobs là danh sách job khai báo trong cấu hình, được ServiceProvider lên lịch với
+	// handler đã đăng ký theo tên trong JobRegistry của Manager
+	Jobs []JobConfig `mapstructure:"jobs" yaml:"jobs"`
+
+	// WatchJobs theo dõi file cấu hình và đối chiếu lại Jobs qua Manager.SyncJobs khi file thay đổi
+	WatchJobs bool `mapstructure:"watch_jobs" yaml:"watch_jobs"`
+
+	// Metrics chứa cấu hình thu thập metrics với Prometheus
+	Metrics MetricsConfig `mapstructure:"metrics" yaml:"metrics"`
+
+	// Tracing chứa cấu hình tạo span OpenTelemetry cho các lần chạy job
+	Tracing TracingConfig `mapstructure:"tracing" yaml:"tracing"`
+
+	// History chứa cấu hình lưu lịch sử các lần chạy job
+	History HistoryConfig `mapstructure:"history" yaml:"history"`
+}
+
+// JobConfig khai báo một job trong cấu hình.
+//
+// Lịch chạy được xác định bởi đúng một trong Cron, Interval hoặc At.
+type JobConfig struct {
+	// Name là tên duy nhất của job, cũng là lock key khi dùng distributed locking
+	Name string `mapstructure:"name" yaml:"name"`
+
+	// Handler là tên handler trong JobRegistry, để trống sẽ sử dụng Name
+	Handler string `mapstructure:"handler" yaml:"handler"`
+
+	// Cron là biểu thức cron 5 trường, hoặc 6 trường nếu có giây
+	Cron string `mapstructure:"cron" yaml:"cron"`
+
+	// Interval là khoảng thời gian giữa các lần chạy theo định dạng time.ParseDuration (ví dụ "30s", "5m")
+	Interval string `mapstructure:"interval" yaml:"interval"`
+
+	// At là thời điểm chạy hằng ngày theo định dạng "HH:MM" hoặc "HH:MM:SS"
+	At string `mapstructure:"at" yaml:"at"`
+
+	// Tags là các tag gán cho job
+	Tags []string `mapstructure:"tags" yaml:"tags"`
+
+	// Singleton không cho phép job chạy đồng thời với chính nó
+	Singleton bool `mapstructure:"singleton" yaml:"singleton"`
+
+	// Timeout là thời gian chạy tối đa (giây) của mỗi lần chạy, 0 nghĩa là dùng DefaultTimeout
+	Timeout int `mapstructure:"timeout" yaml:"timeout"`
+
+	// Retry là chính sách thử lại khi job thất bại, để trống nghĩa là không thử lại
+	Retry *RetryConfig `mapstructure:"retry" yaml:"retry"`
+
+	// Lock xác định job có lấy khóa phân tán ở chế độ per_job_lock không
+	// Để trống tương đương true
+	Lock *bool `mapstructure:"lock" yaml:"lock"`
+
+	// Enabled xác định job có được lên lịch không
+	// Để trống tương đương true
+	Enabled *bool `mapstructure:"enabled" yaml:"enabled"`
+}
+
+// HandlerName trả về tên handler của job: Handler nếu được thiết lập, ngược lại là Name.
+func (j JobConfig) HandlerName() string {
+	if j.Handler != "" {
+		return j.Handler
+	}
+	return j.Name
+}
+
+// IsEnabled trả về true nếu job được lên lịch (Enabled trống hoặc true).
+func (j JobConfig) IsEnabled() bool {
+	return j.Enabled == nil || *j.Enabled
+}
+
+// UsesLock trả về true nếu job lấy khóa phân tán (Lock trống hoặc true).
+func (j JobConfig) UsesLock() bool {
+	return j.Lock == nil || *j.Lock
+}
+
+// Schedule trả về lịch chạy của job.
+func (j JobConfig) Schedule() JobSchedule {
+	return JobSchedule{Cron: j.Cron, Interval: j.Interval, At: j.At}
+}
+
+// Validate kiểm tra tính hợp lệ của JobConfig.
+func (j JobConfig) Validate() error {
+	if j.Name == "" {
+		return ErrJobNameRequired
+	}
+
+	if err := j.Schedule().Validate(); err != nil {
+		return err
+	}
+	if j.Timeout < 0 {
+		return ErrInvalidJobTimeout
+	}
+	if j.Retry != nil {
+		if err := j.Retry.ToRetryPolicy().Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// JobSchedule là lịch chạy của job, được dùng bởi JobConfig và Manager.Reschedule.
+// Chỉ một trong Cron, Interval hoặc At được thiết lập.
+type JobSchedule struct {
+	// Cron là biểu thức cron 5 trường, hoặc 6 trường nếu có giây
+	Cron string `mapstructure:"cron" yaml:"cron" json:"cron,omitempty"`
+
+	// Interval là khoảng thời gian giữa các lần chạy theo định dạng time.ParseDuration (ví dụ "30s", "5m")
+	Interval string `mapstructure:"interval" yaml:"interval" json:"interval,omitempty"`
+
+	// At là thời điểm chạy hằng ngày theo định dạng "HH:MM" hoặc "HH:MM:SS"
+	At string `mapstructure:"at" yaml:"at" json:"at,omitempty"`
+}
+
+// Validate kiểm tra chỉ một lịch chạy được thiết lập và Interval (nếu có) hợp lệ.
+func (s JobSchedule) Validate() error {
+	schedules := 0
+	for _, value := range []string{s.Cron, s.Interval, s.At} {
+		if value != "" {
+			schedules++
+		}
+	}
+	if schedules != 1 {
+		return ErrInvalidJobSchedule
+	}
+
+	if s.Interval != "" {
+		if d, err := time.ParseDuration(s.Interval); err != nil || d <= 0 {
+			return ErrInvalidJobInterval
+		}
+	}
+	return nil
+}
+
+// MetricsConfig chứa cấu hình thu thập metrics với PrometheusCollector.
+type MetricsConfig struct {
+	// Enabled bật thu thập metrics của job và khóa phân tán
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Namespace là tiền tố tên các metrics, để trống sẽ dùng "scheduler"
+	Namespace string `mapstructure:"namespace" yaml:"namespace"`
+
+	// Registerer là key trong DI container của prometheus.Registerer
+	// Để trống sẽ sử dụng prometheus.DefaultRegisterer
+	Registerer string `mapstructure:"registerer" yaml:"registerer"`
+}
+
+// TracingConfig chứa cấu hình tạo span OpenTelemetry cho các lần chạy job.
+type TracingConfig struct {
+	// Enabled bật tạo span cho mỗi lần chạy job và lần lấy khóa phân tán
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// TracerProvider là key trong DI container của trace.TracerProvider
+	// Để trống sẽ sử dụng otel.GetTracerProvider()
+	TracerProvider string `mapstructure:"tracer_provider" yaml:"tracer_provider"`
+}
+
+// HistoryConfig chứa cấu hình lưu lịch sử các lần chạy job với HistoryStore.
+type HistoryConfig struct {
+	// Enabled bật lưu lịch sử các lần chạy job
+	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Driver chọn backend lưu lịch sử: "memory", "redis", "postgres", "mysql" hoặc "sqlite"
+	// Để trống tương đương "memory"
+	Driver string `mapstructure:"driver" yaml:"driver"`
+
+	// Database là key trong DI container của *sql.DB dùng cho các driver SQL
+	// Để trống tương đương "db"
+	Database string `mapstructure:"database" yaml:"database"`
+
+	// RedisClient chọn client lấy từ redis provider: "default" hoặc "universal"
+	// Để trống tương đương "default"
+	RedisClient string `mapstructure:"redis_client" yaml:"redis_client"`
+
+	// KeyPrefix là tiền tố các key lịch sử trong Redis, để trống sẽ dùng "scheduler_history:"
+	KeyPrefix string `mapstructure:"key_prefix" yaml:"key_prefix"`
+
+	// MaxEntries là số lần chạy tối đa được giữ cho mỗi job với driver memory và redis
+	// 0 sẽ sử dụng DefaultHistoryMaxEntries
+	MaxEntries int `mapstructure:"max_entries" yaml:"max_entries"`
+}
+
+// DriverName trả về driver lưu lịch sử được sử dụng, mặc định là "memory".
+func (c HistoryConfig) DriverName() string {
+	if c.Driver == "" {
+		return HistoryDriverMemory
+	}
+	return c.Driver
 }
 
 // DistributedLockConfig chứa cấu hình cho distributed locking.
@@ -23,6 +303,30 @@ type DistributedLockConfig struct {
 	// Enabled xác định có bật distributed locking không
 	// Chỉ cần thiết khi chạy scheduler trên nhiều instance trong môi trường phân tán
 	Enabled bool `mapstructure:"enabled" yaml:"enabled"`
+
+	// Mode chọn cơ chế phân tán: "per_job_lock" (khóa theo từng job) hoặc "leader_election"
+	// Để trống tương đương "per_job_lock"
+	Mode string `mapstructure:"mode" yaml:"mode"`
+
+	// Backend là tên locker backend đã đăng ký qua RegisterLockerBackend
+	// Để trống sẽ sử dụng Driver
+	Backend string `mapstructure:"backend" yaml:"backend"`
+
+	// Driver chọn backend có sẵn cho khóa: "redis", "postgres", "mysql" hoặc "sqlite"
+	// Để trống tương đương "redis"
+	Driver string `mapstructure:"driver" yaml:"driver"`
+
+	// Database là key trong DI container của *sql.DB dùng cho các driver SQL
+	// Để trống tương đương "db"
+	Database string `mapstructure:"database" yaml:"database"`
+
+	// RedisClient chọn client lấy từ redis provider: "default" hoặc "universal" (Cluster, Sentinel, Ring)
+	// Để trống tương đương "default"
+	RedisClient string `mapstructure:"redis_client" yaml:"redis_client"`
+
+	// SharePauseState lưu trạng thái tạm dừng của job trong backend để dùng chung giữa các instance
+	// Mặc định trạng thái tạm dừng chỉ áp dụng cho instance hiện tại
+	SharePauseState bool `mapstructure:"share_pause_state" yaml:"share_pause_state"`
 }
 
 // RedisLockerOptions chứa các tùy chọn cấu hình cho Redis Locker.
@@ -38,12 +342,22 @@ type RedisLockerOptions struct {
 
 	// RetryDelay là thời gian chờ giữa các lần thử (milliseconds)
 	RetryDelay int `mapstructure:"retry_delay" yaml:"retry_delay"`
+
+	// InstanceID định danh instance hiện tại, được dùng làm tiền tố cho owner token của khóa
+	// Để trống sẽ tự động sinh từ hostname và process id
+	InstanceID string `mapstructure:"instance_id" yaml:"instance_id"`
+
+	// HashTag bọc tên job trong hash tag của Redis Cluster ("prefix{job}"), đảm bảo mọi key
+	// liên quan tới một job nằm trên cùng một slot
+	HashTag bool `mapstructure:"hash_tag" yaml:"hash_tag"`
 }
 
 // DefaultConfig trả về cấu hình mặc định cho scheduler.
 func DefaultConfig() Config {
 	return Config{
-		AutoStart: true,
+		AutoStart:       true,
+		LimitMode:       LimitModeReschedule,
+		ShutdownTimeout: 30, // 30 seconds
 		DistributedLock: DistributedLockConfig{
 			Enabled: false,
 		},
@@ -51,6 +365,128 @@ func DefaultConfig() Config {
 	}
 }
 
+// BackendName trả về tên locker backend được sử dụng: Backend nếu được thiết lập,
+// tiếp theo là Driver, mặc định là "redis".
+func (c DistributedLockConfig) BackendName() string {
+	if c.Backend != "" {
+		return c.Backend
+	}
+	if c.Driver != "" {
+		return c.Driver
+	}
+	return LockDriverRedis
+}
+
+// GetLocation trả về múi giờ mà scheduler sử dụng.
+//
+// Location được ưu tiên nếu đã được thiết lập, tiếp theo là Timezone.
+// Nếu cả hai đều trống, time.Local được sử dụng. Timezone không hợp lệ trả về lỗi bọc
+// ErrInvalidTimezone kèm tên múi giờ và lỗi từ time.LoadLocation.
+func (c Config) GetLocation() (*time.Location, error) {
+	if c.Location != nil {
+		return c.Location, nil
+	}
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrInvalidTimezone, c.Timezone, err)
+	}
+	return loc, nil
+}
+
+// GetLogLevel trả về slog.Level tương ứng với LogLevel, mặc định là slog.LevelInfo.
+func (c Config) GetLogLevel() (slog.Level, error) {
+	switch strings.ToLower(c.LogLevel) {
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, ErrInvalidLogLevel
+	}
+}
+
+// Validate kiểm tra tính hợp lệ của các tùy chọn cấp scheduler trong Config.
+func (c Config) Validate() error {
+	if _, err := c.GetLocation(); err != nil {
+		return err
+	}
+	if c.MaxConcurrentJobs < 0 {
+		return ErrInvalidMaxConcurrentJobs
+	}
+	switch c.LimitMode {
+	case "", LimitModeReschedule, LimitModeWait:
+	default:
+		return ErrInvalidLimitMode
+	}
+	if _, err := c.GetLogLevel(); err != nil {
+		return err
+	}
+	if c.ShutdownTimeout < 0 {
+		return ErrInvalidShutdownTimeout
+	}
+	if c.DefaultTimeout < 0 {
+		return ErrInvalidJobTimeout
+	}
+	switch c.DistributedLock.Driver {
+	case "", LockDriverRedis, LockDriverPostgres, LockDriverMySQL, LockDriverSQLite:
+	default:
+		return ErrUnsupportedLockDriver
+	}
+	switch c.DistributedLock.Mode {
+	case "", LockModePerJob, LockModeLeaderElection:
+	default:
+		return ErrInvalidLockMode
+	}
+	if c.DistributedLock.Enabled {
+		backend, ok := lookupLockerBackend(c.DistributedLock.BackendName())
+		if !ok {
+			return ErrUnknownLockerBackend
+		}
+		if c.DistributedLock.Mode == LockModeLeaderElection && backend.Elector == nil {
+			return ErrLeaderElectionNotSupported
+		}
+		if c.DistributedLock.SharePauseState && backend.PauseStore == nil {
+			return ErrPauseStateNotSupported
+		}
+	}
+	switch c.DistributedLock.RedisClient {
+	case "", RedisClientDefault, RedisClientUniversal:
+	default:
+		return ErrInvalidRedisClient
+	}
+	switch c.History.DriverName() {
+	case HistoryDriverMemory, HistoryDriverRedis, HistoryDriverPostgres, HistoryDriverMySQL, HistoryDriverSQLite:
+	default:
+		return ErrUnsupportedHistoryDriver
+	}
+	switch c.History.RedisClient {
+	case "", RedisClientDefault, RedisClientUniversal:
+	default:
+		return ErrInvalidRedisClient
+	}
+	if c.History.MaxEntries < 0 {
+		return ErrInvalidHistoryMaxEntries
+	}
+	names := make(map[string]bool, len(c.Jobs))
+	for _, job := range c.Jobs {
+		if err := job.Validate(); err != nil {
+			return fmt.Errorf("%w (job %q)", err, job.Name)
+		}
+		if names[job.Name] {
+			return fmt.Errorf("%w (job %q)", ErrDuplicateJobName, job.Name)
+		}
+		names[job.Name] = true
+	}
+	return nil
+}
+
 // DefaultRedisLockerOptions trả về các tùy chọn mặc định cho Redis Locker.
 func DefaultRedisLockerOptions() RedisLockerOptions {
 	return RedisLockerOptions{
@@ -68,6 +504,8 @@ func (opts RedisLockerOptions) ToTimeDuration() RedisLockerOptionsTime {
 		LockDuration: time.Duration(opts.LockDuration) * time.Second,
 		MaxRetries:   opts.MaxRetries,
 		RetryDelay:   time.Duration(opts.RetryDelay) * time.Millisecond,
+		InstanceID:   opts.InstanceID,
+		HashTag:      opts.HashTag,
 	}
 }
 
@@ -84,4 +522,58 @@ type RedisLockerOptionsTime struct {
 
 	// RetryDelay là thời gian chờ giữa các lần thử
 	RetryDelay time.Duration
+
+	// InstanceID định danh instance hiện tại, được dùng làm tiền tố cho owner token của khóa
+	InstanceID string
+
+	// HashTag bọc tên job trong hash tag của Redis Cluster
+	HashTag bool
 }
+
+// Error constants cho cấu hình scheduler
+var (
+	// ErrInvalidTimezone được trả về khi Timezone không phải là tên múi giờ IANA hợp lệ.
+	ErrInvalidTimezone = errors.New("scheduler: invalid timezone")
+
+	// ErrInvalidMaxConcurrentJobs được trả về khi MaxConcurrentJobs âm.
+	ErrInvalidMaxConcurrentJobs = errors.New("scheduler: invalid max concurrent jobs")
+
+	// ErrInvalidLimitMode được trả về khi LimitMode không phải "reschedule" hoặc "wait".
+	ErrInvalidLimitMode = errors.New("scheduler: invalid limit mode")
+
+	// ErrInvalidLogLevel được trả về khi LogLevel không phải "debug", "info", "warn" hoặc "error".
+	ErrInvalidLogLevel = errors.New("scheduler: invalid log level")
+
+	// ErrInvalidShutdownTimeout được trả về khi ShutdownTimeout âm.
+	ErrInvalidShutdownTimeout = errors.New("scheduler: invalid shutdown timeout")
+
+	// ErrInvalidRedisClient được trả về khi DistributedLock.RedisClient không phải "default" hoặc "universal".
+	ErrInvalidRedisClient = errors.New("scheduler: invalid redis client")
+
+	// ErrUnknownLockerBackend được trả về khi DistributedLock.Backend chưa được đăng ký.
+	ErrUnknownLockerBackend = errors.New("scheduler: unknown locker backend")
+
+	// ErrInvalidLockMode được trả về khi DistributedLock.Mode không phải "per_job_lock" hoặc "leader_election".
+	ErrInvalidLockMode = errors.New("scheduler: invalid distributed lock mode")
+
+	// ErrLeaderElectionNotSupported được trả về khi locker backend không hỗ trợ leader election.
+	ErrLeaderElectionNotSupported = errors.New("scheduler: locker backend does not support leader election")
+
+	// ErrPauseStateNotSupported được trả về khi locker backend không hỗ trợ chia sẻ trạng thái tạm dừng.
+	ErrPauseStateNotSupported = errors.New("scheduler: locker backend does not support shared pause state")
+
+	// ErrJobNameRequired được trả về khi JobConfig không có Name.
+	ErrJobNameRequired = errors.New("scheduler: job name is required")
+
+	// ErrDuplicateJobName được trả về khi nhiều JobConfig có cùng Name.
+	ErrDuplicateJobName = errors.New("scheduler: duplicate job name")
+
+	// ErrInvalidJobSchedule được trả về khi JobConfig không có hoặc có nhiều hơn một trong Cron, Interval, At.
+	ErrInvalidJobSchedule = errors.New("scheduler: job must have exactly one of cron, interval or at")
+
+	// ErrInvalidJobInterval được trả về khi JobConfig.Interval không phải khoảng thời gian dương hợp lệ.
+	ErrInvalidJobInterval = errors.New("scheduler: invalid job interval")
+
+	// ErrInvalidJobTimeout được trả về khi JobConfig.Timeout, Config.DefaultTimeout hoặc Manager.Timeout âm.
+	ErrInvalidJobTimeout = errors.New("scheduler: invalid job timeout")
+)
@@ -178,3 +178,270 @@ func TestRedisLockerOptionsValidValues(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultConfigSchedulerOptions(t *testing.T) {
+	config := DefaultConfig()
+
+	assert.Empty(t, config.Timezone, "Timezone should be empty by default")
+	assert.Nil(t, config.Location, "Location should be nil by default")
+	assert.Equal(t, 0, config.MaxConcurrentJobs, "MaxConcurrentJobs should be unlimited by default")
+	assert.Equal(t, LimitModeReschedule, config.LimitMode)
+	assert.Equal(t, 30, config.ShutdownTimeout)
+	assert.False(t, config.TagsUnique)
+	assert.False(t, config.WaitForSchedule)
+}
+
+func TestConfigGetLocation(t *testing.T) {
+	hcm, err := time.LoadLocation("Asia/Ho_Chi_Minh")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		config   Config
+		expected *time.Location
+		wantErr  error
+	}{
+		{
+			name:     "empty timezone uses local",
+			config:   Config{},
+			expected: time.Local,
+		},
+		{
+			name:     "timezone is loaded",
+			config:   Config{Timezone: "Asia/Ho_Chi_Minh"},
+			expected: hcm,
+		},
+		{
+			name:     "location takes precedence over timezone",
+			config:   Config{Timezone: "Asia/Ho_Chi_Minh", Location: time.UTC},
+			expected: time.UTC,
+		},
+		{
+			name:    "invalid timezone",
+			config:  Config{Timezone: "Mars/Olympus_Mons"},
+			wantErr: ErrInvalidTimezone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := tt.config.GetLocation()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Contains(t, err.Error(), tt.config.Timezone)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected.String(), loc.String())
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr error
+	}{
+		{
+			name:   "default config is valid",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "wait limit mode is valid",
+			modify: func(c *Config) {
+				c.MaxConcurrentJobs = 5
+				c.LimitMode = LimitModeWait
+			},
+		},
+		{
+			name:    "invalid timezone",
+			modify:  func(c *Config) { c.Timezone = "Invalid/Zone" },
+			wantErr: ErrInvalidTimezone,
+		},
+		{
+			name:    "negative max concurrent jobs",
+			modify:  func(c *Config) { c.MaxConcurrentJobs = -1 },
+			wantErr: ErrInvalidMaxConcurrentJobs,
+		},
+		{
+			name:    "unknown limit mode",
+			modify:  func(c *Config) { c.LimitMode = "drop" },
+			wantErr: ErrInvalidLimitMode,
+		},
+		{
+			name:    "negative shutdown timeout",
+			modify:  func(c *Config) { c.ShutdownTimeout = -1 },
+			wantErr: ErrInvalidShutdownTimeout,
+		},
+		{
+			name:    "negative default timeout",
+			modify:  func(c *Config) { c.DefaultTimeout = -1 },
+			wantErr: ErrInvalidJobTimeout,
+		},
+		{
+			name:    "unknown log level",
+			modify:  func(c *Config) { c.LogLevel = "verbose" },
+			wantErr: ErrInvalidLogLevel,
+		},
+		{
+			name:   "debug log level is valid",
+			modify: func(c *Config) { c.LogLevel = "DEBUG" },
+		},
+		{
+			name:   "sqlite lock driver is valid",
+			modify: func(c *Config) { c.DistributedLock.Driver = LockDriverSQLite },
+		},
+		{
+			name:    "unknown lock driver",
+			modify:  func(c *Config) { c.DistributedLock.Driver = "oracle" },
+			wantErr: ErrUnsupportedLockDriver,
+		},
+		{
+			name: "unknown locker backend",
+			modify: func(c *Config) {
+				c.DistributedLock.Enabled = true
+				c.DistributedLock.Backend = "missing"
+			},
+			wantErr: ErrUnknownLockerBackend,
+		},
+		{
+			name:    "unknown lock mode",
+			modify:  func(c *Config) { c.DistributedLock.Mode = "quorum" },
+			wantErr: ErrInvalidLockMode,
+		},
+		{
+			name: "leader election with redis backend is valid",
+			modify: func(c *Config) {
+				c.DistributedLock.Enabled = true
+				c.DistributedLock.Mode = LockModeLeaderElection
+			},
+		},
+		{
+			name: "leader election with sql backend is not supported",
+			modify: func(c *Config) {
+				c.DistributedLock.Enabled = true
+				c.DistributedLock.Mode = LockModeLeaderElection
+				c.DistributedLock.Driver = LockDriverSQLite
+			},
+			wantErr: ErrLeaderElectionNotSupported,
+		},
+		{
+			name:   "universal redis client is valid",
+			modify: func(c *Config) { c.DistributedLock.RedisClient = RedisClientUniversal },
+		},
+		{
+			name:    "unknown redis client",
+			modify:  func(c *Config) { c.DistributedLock.RedisClient = "cluster" },
+			wantErr: ErrInvalidRedisClient,
+		},
+		{
+			name:   "history with sql driver",
+			modify: func(c *Config) { c.History = HistoryConfig{Enabled: true, Driver: HistoryDriverSQLite} },
+		},
+		{
+			name:    "unknown history driver",
+			modify:  func(c *Config) { c.History.Driver = "mongodb" },
+			wantErr: ErrUnsupportedHistoryDriver,
+		},
+		{
+			name:    "unknown history redis client",
+			modify:  func(c *Config) { c.History.RedisClient = "cluster" },
+			wantErr: ErrInvalidRedisClient,
+		},
+		{
+			name:    "negative history max entries",
+			modify:  func(c *Config) { c.History.MaxEntries = -1 },
+			wantErr: ErrInvalidHistoryMaxEntries,
+		},
+		{
+			name: "declared jobs are valid",
+			modify: func(c *Config) {
+				c.Jobs = []JobConfig{
+					{Name: "cleanup", Cron: "0 3 * * *"},
+					{Name: "sync", Interval: "5m"},
+					{Name: "report", At: "08:30"},
+				}
+			},
+		},
+		{
+			name:    "job without name",
+			modify:  func(c *Config) { c.Jobs = []JobConfig{{Interval: "5m"}} },
+			wantErr: ErrJobNameRequired,
+		},
+		{
+			name:    "job without schedule",
+			modify:  func(c *Config) { c.Jobs = []JobConfig{{Name: "sync"}} },
+			wantErr: ErrInvalidJobSchedule,
+		},
+		{
+			name:    "job with several schedules",
+			modify:  func(c *Config) { c.Jobs = []JobConfig{{Name: "sync", Cron: "* * * * *", Interval: "5m"}} },
+			wantErr: ErrInvalidJobSchedule,
+		},
+		{
+			name:    "job with invalid interval",
+			modify:  func(c *Config) { c.Jobs = []JobConfig{{Name: "sync", Interval: "often"}} },
+			wantErr: ErrInvalidJobInterval,
+		},
+		{
+			name:    "job with negative timeout",
+			modify:  func(c *Config) { c.Jobs = []JobConfig{{Name: "sync", Interval: "5m", Timeout: -1}} },
+			wantErr: ErrInvalidJobTimeout,
+		},
+		{
+			name:    "job with invalid retry",
+			modify:  func(c *Config) { c.Jobs = []JobConfig{{Name: "sync", Interval: "5m", Retry: &RetryConfig{Jitter: 2}}} },
+			wantErr: ErrInvalidRetryPolicy,
+		},
+		{
+			name: "duplicate job names",
+			modify: func(c *Config) {
+				c.Jobs = []JobConfig{{Name: "sync", Interval: "5m"}, {Name: "sync", Cron: "* * * * *"}}
+			},
+			wantErr: ErrDuplicateJobName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(&config)
+			err := config.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJobConfigDefaults(t *testing.T) {
+	job := JobConfig{Name: "sync"}
+
+	assert.Equal(t, "sync", job.HandlerName())
+	assert.True(t, job.IsEnabled())
+	assert.True(t, job.UsesLock())
+
+	disabled := false
+	job.Handler = "orders.sync"
+	job.Enabled = &disabled
+	job.Lock = &disabled
+
+	assert.Equal(t, "orders.sync", job.HandlerName())
+	assert.False(t, job.IsEnabled())
+	assert.False(t, job.UsesLock())
+}
+
+func TestJobScheduleValidate(t *testing.T) {
+	assert.NoError(t, JobSchedule{Cron: "0 3 * * *"}.Validate())
+	assert.NoError(t, JobSchedule{Interval: "5m"}.Validate())
+	assert.NoError(t, JobSchedule{At: "08:30"}.Validate())
+	assert.ErrorIs(t, JobSchedule{}.Validate(), ErrInvalidJobSchedule)
+	assert.ErrorIs(t, JobSchedule{Cron: "0 3 * * *", At: "08:30"}.Validate(), ErrInvalidJobSchedule)
+	assert.ErrorIs(t, JobSchedule{Interval: "0s"}.Validate(), ErrInvalidJobInterval)
+
+	job := JobConfig{Name: "sync", Interval: "5m"}
+	assert.Equal(t, JobSchedule{Interval: "5m"}, job.Schedule())
+}
@@ -0,0 +1,467 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// JobStatus mô tả trạng thái hiện tại của một job trong scheduler.
+type JobStatus struct {
+	// Name là tên của job
+	Name string
+
+	// Tags là các tag của job
+	Tags []string
+
+	// Schedule mô tả lịch chạy của job, ví dụ "every 5 seconds" hoặc "cron */5 * * * *"
+	Schedule string
+
+	// NextRun là thời điểm chạy kế tiếp, zero nếu scheduler chưa chạy
+	NextRun time.Time
+
+	// LastRun là thời điểm bắt đầu lần chạy gần nhất trên instance này, zero nếu job chưa chạy
+	LastRun time.Time
+
+	// LastError là lỗi của lần chạy gần nhất, nil nếu lần chạy thành công
+	LastError error
+
+	// Running cho biết job có đang chạy trên instance này hay không
+	Running bool
+
+	// Paused cho biết job có đang bị tạm dừng hay không
+	Paused bool
+}
+
+// jobEntry lưu định nghĩa và trạng thái chạy của một job được lên lịch qua DoContext.
+type jobEntry struct {
+	def atomic.Pointer[jobDefinition] // Được thay thế khi job được lên lịch lại qua Reschedule
+	fn  JobFunc
+	job atomic.Pointer[gocron.Job] // Job của gocron, được gán sau khi Do trả về
+
+	exclusive sync.Mutex // Tuần tự hóa các lần chạy của job ở chế độ singleton
+
+	mu      sync.Mutex
+	running int       // Số lần chạy đang diễn ra
+	lastRun time.Time // Thời điểm bắt đầu lần chạy gần nhất
+	lastErr error     // Lỗi của lần chạy gần nhất
+}
+
+// started ghi nhận lần chạy bắt đầu.
+func (e *jobEntry) started() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running++
+	e.lastRun = time.Now()
+}
+
+// finished ghi nhận lần chạy kết thúc với lỗi err.
+func (e *jobEntry) finished(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.running--
+	e.lastErr = err
+}
+
+// manualRunKey đánh dấu context của lần chạy được kích hoạt qua RunNow hoặc RunByTag.
+type manualRunKey struct{}
+
+// Jobs trả về trạng thái của tất cả các job, sắp xếp theo tên.
+func (m *manager) Jobs() []JobStatus {
+	jobs, entries := m.scheduledEntries()
+	paused := m.pauseState()
+
+	statuses := make([]JobStatus, 0, len(jobs))
+	for i, job := range jobs {
+		status := JobStatus{
+			Name:    job.GetName(),
+			Tags:    append([]string(nil), job.Tags()...),
+			NextRun: job.NextRun(),
+			Running: job.IsRunning(),
+		}
+		if entry := entries[i]; entry != nil {
+			def := entry.def.Load()
+			entry.mu.Lock()
+			status.Tags = append([]string(nil), def.tags...)
+			status.Schedule = def.schedule
+			status.LastRun = entry.lastRun
+			status.LastError = entry.lastErr
+			status.Running = status.Running || entry.running > 0
+			entry.mu.Unlock()
+		}
+		status.Running = status.Running || m.orphaned(status.Name)
+		status.Paused = paused.IsPaused(status.Name, status.Tags)
+		statuses = append(statuses, status)
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// RunNow chạy ngay các job có tên name.
+func (m *manager) RunNow(name string) (*RunHandle, error) {
+	entries := m.entriesByName(name)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return m.trigger(entries), nil
+}
+
+// RunByTag chạy ngay các job có tag.
+func (m *manager) RunByTag(tag string) (*RunHandle, error) {
+	entries := m.entriesByTag(tag)
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%w: tag %s", ErrJobNotFound, tag)
+	}
+	return m.trigger(entries), nil
+}
+
+// Pause tạm dừng các job có tên name.
+func (m *manager) Pause(name string) error {
+	if len(m.entriesByName(name)) == 0 {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return m.setPaused(name, false, true)
+}
+
+// Resume tiếp tục các job có tên name.
+func (m *manager) Resume(name string) error {
+	if len(m.entriesByName(name)) == 0 {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	return m.setPaused(name, false, false)
+}
+
+// PauseByTag tạm dừng các job có tag.
+func (m *manager) PauseByTag(tag string) error {
+	if len(m.entriesByTag(tag)) == 0 {
+		return fmt.Errorf("%w: tag %s", ErrJobNotFound, tag)
+	}
+	return m.setPaused(tag, true, true)
+}
+
+// ResumeByTag tiếp tục các job có tag.
+func (m *manager) ResumeByTag(tag string) error {
+	if len(m.entriesByTag(tag)) == 0 {
+		return fmt.Errorf("%w: tag %s", ErrJobNotFound, tag)
+	}
+	return m.setPaused(tag, true, false)
+}
+
+// IsPaused kiểm tra job có tên name có đang bị tạm dừng hay không.
+func (m *manager) IsPaused(name string) bool {
+	return m.jobPaused(name)
+}
+
+// PauseState trả về các job và tag đang bị tạm dừng.
+func (m *manager) PauseState(ctx context.Context) (PauseState, error) {
+	return m.pauses.Load(ctx)
+}
+
+// RemoveByName xóa các job có tên name.
+func (m *manager) RemoveByName(name string) error {
+	var removed bool
+	for _, job := range m.Scheduler.Jobs() {
+		if job.GetName() == name {
+			m.Scheduler.RemoveByReference(job)
+			removed = true
+		}
+	}
+	if !removed {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	m.scheduledEntries()
+	return nil
+}
+
+// Reschedule thay lịch chạy của các job có tên name bằng schedule.
+func (m *manager) Reschedule(name string, schedule JobSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	if err := schedule.check(m.Scheduler.Location()); err != nil {
+		return err
+	}
+
+	m.syncMu.Lock()
+	defer m.syncMu.Unlock()
+
+	entries := m.entriesByName(name)
+	if len(entries) == 0 {
+		return fmt.Errorf("%w: %s", ErrJobNotFound, name)
+	}
+	replaced, err := m.reschedule(entries, schedule)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+	}
+
+	// Job khai báo trong cấu hình: lịch chạy mới được giữ qua SyncJobs cho tới khi lịch chạy
+	// trong cấu hình thay đổi
+	if current, ok := m.configured[name]; ok {
+		if job, ok := replaced[current.job]; ok {
+			current.job = job
+			m.configured[name] = current
+			if schedule == current.config.Schedule() {
+				delete(m.overrides, name)
+			} else {
+				m.overrides[name] = schedule
+			}
+		}
+	}
+
+	m.events.emit(Event{Type: EventJobRescheduled, JobName: name, Tags: entries[0].def.Load().tags})
+	return nil
+}
+
+// reschedule thay job của gocron thuộc các entry bằng job mới với lịch chạy schedule và trả về
+// job mới theo job cũ. Định nghĩa, hàm, trạng thái chạy và khóa singleton của entry được giữ
+// nguyên; lần chạy đang diễn ra của job cũ không bị gián đoạn. Job mới chờ tới lịch chạy kế tiếp.
+//
+// Mọi job mới được tạo trước khi job cũ nào bị xóa: nếu không tạo được một job, các job mới đã
+// tạo bị xóa và các job cũ được giữ nguyên. Caller phải giữ m.syncMu.
+func (m *manager) reschedule(entries []*jobEntry, schedule JobSchedule) (map[*gocron.Job]*gocron.Job, error) {
+	m.entriesMu.Lock()
+	defer m.entriesMu.Unlock()
+
+	// Job mới chưa có tag để không vi phạm TagsUnique khi job cũ vẫn còn trong scheduler; Jobs,
+	// RunNow và các thao tác khác theo tên chờ entriesMu nên không thấy job cũ và job mới cùng lúc
+	jobs := make([]*gocron.Job, 0, len(entries))
+	for _, entry := range entries {
+		schedule.apply(m.Scheduler)
+		m.Scheduler.Name(entry.def.Load().name)
+		m.Scheduler.WaitForSchedule()
+		job, err := m.Scheduler.Do(func() error {
+			return m.runScheduled(entry)
+		})
+		if err != nil {
+			for _, job := range jobs {
+				m.Scheduler.RemoveByReference(job)
+			}
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	replaced := make(map[*gocron.Job]*gocron.Job, len(entries))
+	for i, entry := range entries {
+		def := *entry.def.Load()
+		def.schedule = schedule.describe()
+
+		// Tag được bỏ khỏi job cũ trước khi xóa để tag vẫn được giữ trong TagsUnique của scheduler
+		// cho job mới
+		old := entry.job.Load()
+		for _, tag := range def.tags {
+			old.Untag(tag)
+		}
+		m.Scheduler.RemoveByReference(old)
+		delete(m.entries, old)
+		if len(def.tags) > 0 {
+			jobs[i].Tag(def.tags...)
+		}
+
+		entry.def.Store(&def)
+		entry.job.Store(jobs[i])
+		m.entries[jobs[i]] = entry
+		replaced[old] = jobs[i]
+	}
+	return replaced, nil
+}
+
+// jobPaused kiểm tra job có tên name (cũng là lock key của job) có đang bị tạm dừng hay không.
+func (m *manager) jobPaused(name string) bool {
+	state := m.pauseState()
+	if state.IsPaused(name, nil) {
+		return true
+	}
+	if len(state.Tags) == 0 {
+		// Không có tag nào bị tạm dừng, không cần tìm tag của job
+		return false
+	}
+	for _, entry := range m.entriesByName(name) {
+		if state.IsPaused(name, entry.def.Load().tags) {
+			return true
+		}
+	}
+	return false
+}
+
+// addEntry ghi nhận entry của job vừa được lên lịch.
+func (m *manager) addEntry(job *gocron.Job, entry *jobEntry) {
+	m.entriesMu.Lock()
+	defer m.entriesMu.Unlock()
+	m.entries[job] = entry
+}
+
+// scheduledEntries trả về các job trong scheduler cùng entry tương ứng với từng job (nil nếu job
+// không được lên lịch qua DoContext) và xóa entry của các job không còn trong scheduler.
+func (m *manager) scheduledEntries() ([]*gocron.Job, []*jobEntry) {
+	m.entriesMu.Lock()
+	defer m.entriesMu.Unlock()
+
+	// Danh sách job được lấy khi giữ entriesMu để không xóa entry của job vừa được addEntry
+	jobs := m.Scheduler.Jobs()
+	entries := make([]*jobEntry, len(jobs))
+	current := make(map[*gocron.Job]*jobEntry, len(jobs))
+	for i, job := range jobs {
+		if entry, ok := m.entries[job]; ok {
+			entries[i] = entry
+			current[job] = entry
+		}
+	}
+	m.entries = current
+	return jobs, entries
+}
+
+// entriesByName trả về entry của các job có tên name.
+func (m *manager) entriesByName(name string) []*jobEntry {
+	_, entries := m.scheduledEntries()
+	var matched []*jobEntry
+	for _, entry := range entries {
+		if entry != nil && entry.def.Load().name == name {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
+
+// entriesByTag trả về entry của các job có tag.
+func (m *manager) entriesByTag(tag string) []*jobEntry {
+	_, entries := m.scheduledEntries()
+	var matched []*jobEntry
+	for _, entry := range entries {
+		if entry != nil && slices.Contains(entry.def.Load().tags, tag) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
+
+// runScheduled thực thi lần chạy theo lịch của entry, bỏ qua nếu job đang bị tạm dừng. Trạng
+// thái tạm dừng không được đọc lại nếu đã được kiểm tra khi lấy khóa phân tán của lần chạy này.
+func (m *manager) runScheduled(entry *jobEntry) error {
+	def := entry.def.Load()
+	if !m.locks.takeUnpaused(def.name) && m.pauseState().IsPaused(def.name, def.tags) {
+		m.telemetry.logger().Debug("scheduler: job paused, skipping run", slog.String("job", def.name))
+		return nil
+	}
+	return m.runEntry(entry, scheduledTime(entry.job.Load()), m.locks.takeLock(def.name))
+}
+
+// runEntry thực thi một lần chạy của entry qua runJob và ghi nhận trạng thái của lần chạy.
+// lock là tham chiếu của lần chạy tới khóa phân tán của job, nil nếu không có.
+// Các lần chạy của job ở chế độ singleton được tuần tự hóa, kể cả lần chạy qua RunNow.
+func (m *manager) runEntry(entry *jobEntry, scheduledAt time.Time, lock gocron.Lock) error {
+	def := entry.def.Load()
+	if def.singleton {
+		entry.exclusive.Lock()
+		defer entry.exclusive.Unlock()
+	}
+
+	entry.started()
+	err := m.runJob(def, entry.fn, scheduledAt, lock)
+	entry.finished(err)
+	return err
+}
+
+// trigger chạy ngay các entry trong goroutine riêng, không phụ thuộc lịch chạy và trạng thái
+// tạm dừng của job. Khi có leader elector, chỉ leader chạy job; khi có distributed locker,
+// khóa của job được lấy như lần chạy theo lịch. RunHandle trả về theo dõi kết quả các lần chạy.
+func (m *manager) trigger(entries []*jobEntry) *RunHandle {
+	handle := &RunHandle{
+		done:    make(chan struct{}),
+		results: make([]RunResult, len(entries)),
+	}
+
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		m.triggered.Add(1)
+		wg.Add(1)
+		go func() {
+			defer m.triggered.Done()
+			defer wg.Done()
+			handle.results[i] = m.runTriggered(entry)
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(handle.done)
+	}()
+	return handle
+}
+
+// runTriggered thực thi lần chạy được kích hoạt thủ công của entry và trả về kết quả.
+func (m *manager) runTriggered(entry *jobEntry) RunResult {
+	name := entry.def.Load().name
+	ctx := context.WithValue(context.Background(), manualRunKey{}, true)
+
+	if m.elector != nil {
+		if err := m.elector.IsLeader(ctx); err != nil {
+			m.telemetry.logger().Debug("scheduler: not the leader, skipping triggered run", slog.String("job", name))
+			return RunResult{Name: name, Err: fmt.Errorf("%w: %w", ErrRunSkipped, err), Skipped: true}
+		}
+	}
+
+	// Khóa vẫn có thể đang được giữ bởi instance này sau lần chạy theo lịch gần nhất (gocron
+	// giải phóng khóa muộn để tránh chạy trùng giữa các instance); khi đó lần chạy dùng chung
+	// khóa đó và khóa chỉ được giải phóng sau khi cả lần chạy này kết thúc
+	var lock gocron.Lock
+	if m.locker != nil {
+		lock = m.locks.share(name)
+		var err error
+		if lock == nil {
+			if lock, err = m.locker.Lock(ctx, name); err == nil {
+				// Lần chạy này tự giữ tham chiếu tới khóa vừa lấy
+				m.locks.takeLock(name)
+			}
+		}
+		if err != nil || lock == nil {
+			// trackingLocker đã ghi nhận lần chạy bị bỏ qua
+			result := RunResult{Name: name, Err: ErrRunSkipped, Skipped: true}
+			if err != nil {
+				result.Err = fmt.Errorf("%w: %w", ErrRunSkipped, err)
+			}
+			return result
+		}
+		defer func() {
+			if err := lock.Unlock(ctx); err != nil {
+				m.telemetry.logger().Warn("scheduler: failed to release lock", slog.String("key", name), slog.Any("error", err))
+			}
+		}()
+	}
+
+	return RunResult{Name: name, Err: m.runEntry(entry, time.Now(), lock)}
+}
+
+// isTriggered kiểm tra ctx có thuộc lần chạy được kích hoạt qua RunNow hoặc RunByTag hay không.
+func isTriggered(ctx context.Context) bool {
+	triggered, _ := ctx.Value(manualRunKey{}).(bool)
+	return triggered
+}
+
+// Error constants cho việc điều khiển job
+var (
+	// ErrJobNotFound được trả về khi không có job nào khớp với tên hoặc tag đã chỉ định.
+	ErrJobNotFound = errors.New("scheduler: job not found")
+
+	// ErrJobPaused được trả về bởi distributed locker của scheduler khi job đang bị tạm dừng,
+	// để lần chạy theo lịch bị bỏ qua mà không lấy khóa.
+	ErrJobPaused = errors.New("scheduler: job is paused")
+
+	// ErrInvalidSchedule được trả về bởi Reschedule và SyncJobs khi gocron không chấp nhận
+	// lịch chạy, ví dụ biểu thức cron sai.
+	ErrInvalidSchedule = errors.New("scheduler: invalid job schedule")
+
+	// ErrRunSkipped được trả về qua RunHandle khi lần chạy được kích hoạt qua RunNow hoặc RunByTag
+	// bị bỏ qua do instance không phải leader hoặc không lấy được khóa phân tán của job.
+	ErrRunSkipped = errors.New("scheduler: triggered run skipped")
+)
@@ -0,0 +1,649 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// waitForStatus chờ tới khi trạng thái của job thỏa mãn cond.
+func waitForStatus(t *testing.T, scheduler Manager, name string, cond func(JobStatus) bool) JobStatus {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, status := range scheduler.Jobs() {
+			if status.Name == name && cond(status) {
+				return status
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Job %s did not reach the expected status", name)
+	return JobStatus{}
+}
+
+func TestManagerJobs(t *testing.T) {
+	scheduler := NewScheduler()
+
+	_, err := scheduler.Every(1).Hours().Name("sync").Tag("billing").DoContext(func(ctx context.Context) error {
+		return errors.New("upstream unavailable")
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, err = scheduler.Cron("*/5 * * * *").Name("cleanup").DoContext(func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	jobs := scheduler.Jobs()
+	if len(jobs) != 2 {
+		t.Fatalf("Expected 2 jobs, got %d", len(jobs))
+	}
+	assert.Equal(t, "cleanup", jobs[0].Name)
+	assert.Equal(t, "cron */5 * * * *", jobs[0].Schedule)
+	assert.Empty(t, jobs[0].Tags)
+	assert.Equal(t, "sync", jobs[1].Name)
+	assert.Equal(t, "every 1 hours", jobs[1].Schedule)
+	assert.Equal(t, []string{"billing"}, jobs[1].Tags)
+	assert.True(t, jobs[1].LastRun.IsZero())
+	assert.False(t, jobs[1].Running)
+	assert.False(t, jobs[1].Paused)
+
+	if _, err := scheduler.RunNow("sync"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	status := waitForStatus(t, scheduler, "sync", func(s JobStatus) bool {
+		return s.LastError != nil && !s.Running
+	})
+	assert.EqualError(t, status.LastError, "upstream unavailable")
+	assert.False(t, status.LastRun.IsZero())
+}
+
+func TestManagerJobsPrunesRemovedJobs(t *testing.T) {
+	scheduler := NewScheduler()
+
+	_, err := scheduler.Every(1).Hours().Name("report").Tag("reports").DoContext(func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := scheduler.RemoveByTag("reports"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assert.Empty(t, scheduler.Jobs())
+	assert.Empty(t, scheduler.(*manager).entries)
+	_, err = scheduler.RunNow("report")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestRunNow(t *testing.T) {
+	scheduler := NewScheduler()
+	defer scheduler.Stop()
+
+	runs := make(chan JobInfo, 1)
+	_, err := scheduler.Every(1).Hours().Name("report").Tag("reports").DoContext(func(ctx context.Context) error {
+		info, _ := JobInfoFromContext(ctx)
+		runs <- info
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Scheduler chưa chạy: job chỉ chạy qua RunNow
+	if _, err := scheduler.RunNow("report"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	select {
+	case info := <-runs:
+		assert.Equal(t, "report", info.Name)
+		assert.Equal(t, 1, info.Attempt)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to run")
+	}
+
+	if _, err := scheduler.RunByTag("reports"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	select {
+	case <-runs:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to run by tag")
+	}
+
+	handle, err := scheduler.RunNow("missing")
+	assert.Nil(t, handle)
+	assert.ErrorIs(t, err, ErrJobNotFound)
+	_, err = scheduler.RunByTag("missing")
+	assert.ErrorIs(t, err, ErrJobNotFound)
+}
+
+func TestRunNowHandle(t *testing.T) {
+	scheduler := NewScheduler()
+	defer scheduler.Stop()
+
+	history := NewMemoryHistoryStore(10)
+	scheduler = scheduler.WithHistory(history)
+
+	release := make(chan struct{})
+	for _, name := range []string{"invoice", "charge"} {
+		_, err := scheduler.Every(1).Hours().Name(name).Tag("billing").DoContext(func(ctx context.Context) error {
+			<-release
+			if name == "charge" {
+				return errors.New("card declined")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	handle, err := scheduler.RunByTag("billing")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.Nil(t, handle.Results(), "results are unavailable until the runs finish")
+	assert.NoError(t, handle.Err())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, handle.Wait(ctx), context.DeadlineExceeded)
+
+	close(release)
+	err = handle.Wait(context.Background())
+	assert.EqualError(t, err, "card declined")
+
+	results := handle.Results()
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	byName := map[string]RunResult{}
+	for _, result := range results {
+		byName[result.Name] = result
+	}
+	assert.NoError(t, byName["invoice"].Err)
+	assert.EqualError(t, byName["charge"].Err, "card declined")
+	assert.False(t, byName["charge"].Skipped)
+
+	select {
+	case <-handle.Done():
+	default:
+		t.Fatal("Expected Done to be closed after Wait")
+	}
+
+	// Lần chạy được ghi vào lịch sử như lần chạy theo lịch
+	runs, err := scheduler.History(context.Background(), "charge", 10, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(runs) != 1 {
+		t.Fatalf("Expected 1 history entry, got %d", len(runs))
+	}
+	assert.Equal(t, "card declined", runs[0].Error)
+}
+
+func TestRunNowRespectsSingletonMode(t *testing.T) {
+	scheduler := NewScheduler()
+	defer scheduler.Stop()
+
+	var running, maxRunning, total atomic.Int32
+	_, err := scheduler.Every(1).Hours().Name("singleton").SingletonMode().DoContext(func(ctx context.Context) error {
+		n := running.Add(1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		running.Add(-1)
+		total.Add(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := scheduler.RunNow("singleton"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	assert.Eventually(t, func() bool { return total.Load() == 3 }, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), maxRunning.Load())
+}
+
+func TestRunNowSkippedWhenLockHeldElsewhere(t *testing.T) {
+	store := NewMemoryLockStore()
+	locker, err := NewMemoryLocker(store, testMemoryLockerOptions("node-a"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	other, err := NewMemoryLocker(store, testMemoryLockerOptions("node-b"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lock, err := other.Lock(context.Background(), "report")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	scheduler := NewScheduler().WithDistributedLocker(locker)
+	defer scheduler.Stop()
+
+	var calls atomic.Int32
+	_, err = scheduler.Every(1).Hours().Name("report").DoContext(func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	handle, err := scheduler.RunNow("report")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.ErrorIs(t, handle.Wait(context.Background()), ErrRunSkipped)
+	assert.True(t, handle.Results()[0].Skipped)
+	assert.Equal(t, int32(0), calls.Load())
+
+	if err := lock.Unlock(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	handle, err = scheduler.RunNow("report")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.NoError(t, handle.Wait(context.Background()))
+	assert.False(t, handle.Results()[0].Skipped)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Nil(t, scheduler.(*manager).locks.get("report"), "lock should be released after the triggered run")
+}
+
+func TestRunNowKeepsLockHeldByScheduledRun(t *testing.T) {
+	store := NewMemoryLockStore()
+	locker, err := NewMemoryLocker(store, testMemoryLockerOptions("node-a"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	other, err := NewMemoryLocker(store, testMemoryLockerOptions("node-b"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	scheduler := NewScheduler().WithDistributedLocker(locker)
+	defer scheduler.Stop()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	_, err = scheduler.Every(1).Hours().Name("report").DoContext(func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Khóa của lần chạy theo lịch gần nhất, gocron giải phóng muộn sau khi job kết thúc
+	scheduled, err := scheduler.(*manager).locker.Lock(context.Background(), "report")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	handle, err := scheduler.RunNow("report")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	<-started
+
+	if err := scheduled.Unlock(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, err = other.Lock(context.Background(), "report")
+	assert.Error(t, err, "lock must stay held while the triggered run is in progress")
+
+	close(release)
+	assert.NoError(t, handle.Wait(context.Background()))
+	lock, err := other.Lock(context.Background(), "report")
+	if err != nil {
+		t.Fatalf("Expected lock to be released after the triggered run: %v", err)
+	}
+	_ = lock.Unlock(context.Background())
+}
+
+func TestPauseAndResume(t *testing.T) {
+	locker := &recordingLocker{lock: noopLock{}}
+	scheduler := NewScheduler().WithDistributedLocker(locker)
+	defer scheduler.Stop()
+
+	var calls atomic.Int32
+	_, err := scheduler.Every(20 * time.Millisecond).Name("poll").DoContext(func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := scheduler.Pause("poll"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.True(t, scheduler.Jobs()[0].Paused)
+
+	scheduler.StartAsync()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), calls.Load(), "paused job should not run on schedule")
+
+	locker.mu.Lock()
+	assert.Empty(t, locker.keys, "paused job should not acquire the lock")
+	locker.mu.Unlock()
+
+	// RunNow chạy job kể cả khi đang tạm dừng
+	if _, err := scheduler.RunNow("poll"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.Eventually(t, func() bool { return calls.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	if err := scheduler.Resume("poll"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.False(t, scheduler.Jobs()[0].Paused)
+	assert.Eventually(t, func() bool { return calls.Load() > 2 }, time.Second, 5*time.Millisecond)
+
+	assert.ErrorIs(t, scheduler.Pause("missing"), ErrJobNotFound)
+	assert.ErrorIs(t, scheduler.Resume("missing"), ErrJobNotFound)
+}
+
+func TestPauseByTag(t *testing.T) {
+	scheduler := NewScheduler()
+
+	for _, name := range []string{"invoice", "charge"} {
+		_, err := scheduler.Every(1).Hours().Name(name).Tag("billing").DoContext(func(ctx context.Context) error {
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	_, err := scheduler.Every(1).Hours().Name("report").DoContext(func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := scheduler.PauseByTag("billing"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	paused := map[string]bool{}
+	for _, status := range scheduler.Jobs() {
+		paused[status.Name] = status.Paused
+	}
+	assert.Equal(t, map[string]bool{"charge": true, "invoice": true, "report": false}, paused)
+
+	// Resume theo tên không tiếp tục job bị tạm dừng theo tag
+	if err := scheduler.Resume("invoice"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.True(t, scheduler.(*manager).jobPaused("invoice"))
+
+	if err := scheduler.ResumeByTag("billing"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, status := range scheduler.Jobs() {
+		assert.False(t, status.Paused, status.Name)
+	}
+
+	assert.ErrorIs(t, scheduler.PauseByTag("missing"), ErrJobNotFound)
+	assert.ErrorIs(t, scheduler.ResumeByTag("missing"), ErrJobNotFound)
+}
+
+func TestRemoveByName(t *testing.T) {
+	scheduler := NewScheduler()
+
+	for _, name := range []string{"report", "report", "cleanup"} {
+		_, err := scheduler.Every(1).Hours().Name(name).DoContext(func(ctx context.Context) error {
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if err := scheduler.RemoveByName("report"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	jobs := scheduler.Jobs()
+	if len(jobs) != 1 {
+		t.Fatalf("Expected 1 jobs, got %d", len(jobs))
+	}
+	assert.Equal(t, "cleanup", jobs[0].Name)
+	assert.ErrorIs(t, scheduler.RemoveByName("report"), ErrJobNotFound)
+}
+
+func TestStopWaitsForTriggeredRuns(t *testing.T) {
+	scheduler := NewScheduler()
+
+	var mu sync.Mutex
+	finished := false
+	started := make(chan struct{})
+	_, err := scheduler.Every(1).Hours().Name("slow").DoContext(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		time.Sleep(20 * time.Millisecond)
+		mu.Lock()
+		finished = true
+		mu.Unlock()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	scheduler.StartAsync()
+	if _, err := scheduler.RunNow("slow"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	<-started
+	scheduler.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.True(t, finished, "Stop should wait for triggered runs")
+}
+
+func TestReschedule(t *testing.T) {
+	scheduler := NewScheduler()
+	defer scheduler.Stop()
+
+	var events []Event
+	var eventsMu sync.Mutex
+	scheduler.OnEvent(func(event Event) {
+		eventsMu.Lock()
+		defer eventsMu.Unlock()
+		if event.Type == EventJobRescheduled {
+			events = append(events, event)
+		}
+	})
+
+	var calls atomic.Int32
+	_, err := scheduler.Every(1).Hours().Name("poll").Tag("polling").SingletonMode().DoContext(func(ctx context.Context) error {
+		calls.Add(1)
+		return errors.New("upstream unavailable")
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	handle, err := scheduler.RunNow("poll")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_ = handle.Wait(context.Background())
+
+	if err := scheduler.Reschedule("poll", JobSchedule{Interval: "20ms"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Tag, trạng thái chạy gần nhất và chế độ singleton được giữ nguyên
+	jobs := scheduler.Jobs()
+	if len(jobs) != 1 {
+		t.Fatalf("Expected 1 job, got %d", len(jobs))
+	}
+	assert.Equal(t, "every 20ms", jobs[0].Schedule)
+	assert.Equal(t, []string{"polling"}, jobs[0].Tags)
+	assert.EqualError(t, jobs[0].LastError, "upstream unavailable")
+	assert.True(t, scheduler.(*manager).entries[scheduler.GetScheduler().Jobs()[0]].def.Load().singleton)
+
+	scheduler.StartAsync()
+	assert.Eventually(t, func() bool { return calls.Load() > 3 }, time.Second, 5*time.Millisecond)
+
+	eventsMu.Lock()
+	rescheduled := append([]Event(nil), events...)
+	eventsMu.Unlock()
+	if len(rescheduled) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(rescheduled))
+	}
+	assert.Equal(t, "poll", rescheduled[0].JobName)
+	assert.Equal(t, []string{"polling"}, rescheduled[0].Tags)
+
+	// Job được lên lịch lại vẫn được xóa theo tag
+	if err := scheduler.RemoveByTag("polling"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.Empty(t, scheduler.Jobs())
+}
+
+func TestRescheduleErrors(t *testing.T) {
+	scheduler := NewScheduler()
+
+	_, err := scheduler.Every(1).Hours().Name("report").DoContext(func(ctx context.Context) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assert.ErrorIs(t, scheduler.Reschedule("missing", JobSchedule{Interval: "5m"}), ErrJobNotFound)
+	assert.ErrorIs(t, scheduler.Reschedule("report", JobSchedule{}), ErrInvalidJobSchedule)
+	assert.ErrorIs(t, scheduler.Reschedule("report", JobSchedule{Cron: "* * * * *", Interval: "5m"}), ErrInvalidJobSchedule)
+	assert.ErrorIs(t, scheduler.Reschedule("report", JobSchedule{Interval: "-5m"}), ErrInvalidJobInterval)
+	assert.ErrorIs(t, scheduler.Reschedule("report", JobSchedule{Cron: "not a cron"}), ErrInvalidSchedule)
+	assert.ErrorIs(t, scheduler.Reschedule("report", JobSchedule{At: "25:99"}), ErrInvalidSchedule)
+
+	// Lịch chạy không hợp lệ không ảnh hưởng tới job
+	jobs := scheduler.Jobs()
+	if len(jobs) != 1 {
+		t.Fatalf("Expected 1 job, got %d", len(jobs))
+	}
+	assert.Equal(t, "every 1 hours", jobs[0].Schedule)
+	assert.Len(t, scheduler.GetScheduler().Jobs(), 1)
+}
+
+func TestRescheduleDoesNotInterruptRunningJob(t *testing.T) {
+	scheduler := NewScheduler()
+	defer scheduler.Stop()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	_, err := scheduler.Every(1).Hours().Name("slow").SingletonMode().DoContext(func(ctx context.Context) error {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	handle, err := scheduler.RunNow("slow")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	<-started
+
+	if err := scheduler.Reschedule("slow", JobSchedule{Cron: "*/5 * * * *"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.True(t, scheduler.Jobs()[0].Running)
+	assert.Equal(t, "cron */5 * * * *", scheduler.Jobs()[0].Schedule)
+
+	close(release)
+	assert.NoError(t, handle.Wait(context.Background()))
+}
+
+func TestRescheduleConfiguredJob(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TagsUnique = true
+	scheduler := NewScheduler(cfg)
+
+	job := JobConfig{Name: "cleanup", Interval: "1h", Tags: []string{"maintenance"}}
+	if _, err := scheduler.ScheduleJob(job); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Tag duy nhất của job không ngăn việc lên lịch lại
+	if err := scheduler.Reschedule("cleanup", JobSchedule{Cron: "0 3 * * *"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.Equal(t, "cron 0 3 * * *", scheduler.Jobs()[0].Schedule)
+	assert.Equal(t, []string{"maintenance"}, scheduler.Jobs()[0].Tags)
+
+	// Tag vẫn thuộc về job sau khi lên lịch lại
+	_, err := scheduler.Every(1).Hours().Name("other").Tag("maintenance").Do(func() {})
+	assert.Error(t, err)
+
+	// SyncJobs với cấu hình không đổi (ví dụ khi file cấu hình được nạp lại) giữ lịch chạy mới
+	gocronJob := scheduler.GetScheduler().Jobs()[0]
+	if err := scheduler.SyncJobs([]JobConfig{job}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.Same(t, gocronJob, scheduler.GetScheduler().Jobs()[0])
+	assert.Equal(t, "cron 0 3 * * *", scheduler.Jobs()[0].Schedule)
+
+	// Thay đổi khác trong cấu hình vẫn giữ lịch chạy mới
+	job.Timeout = 60
+	if err := scheduler.SyncJobs([]JobConfig{job}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.Equal(t, "cron 0 3 * * *", scheduler.Jobs()[0].Schedule)
+
+	// SyncJobs với lịch chạy mới được ghi vào cấu hình không thay đổi job
+	rescheduled := job
+	rescheduled.Interval, rescheduled.Cron = "", "0 3 * * *"
+	gocronJob = scheduler.GetScheduler().Jobs()[0]
+	if err := scheduler.SyncJobs([]JobConfig{rescheduled}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assert.Same(t, gocronJob, scheduler.GetScheduler().Jobs()[0])
+
+	// Lịch chạy trong cấu hình thay đổi được ưu tiên
+	if err := scheduler.Reschedule("cleanup", JobSchedule{Interval: "30m"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	job.Interval = "2h"
+	if err := scheduler.SyncJobs([]JobConfig{job}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	jobs := scheduler.Jobs()
+	if len(jobs) != 1 {
+		t.Fatalf("Expected 1 job, got %d", len(jobs))
+	}
+	assert.Equal(t, "every 2h0m0s", jobs[0].Schedule)
+}
@@ -0,0 +1,283 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/redis/go-redis/v9"
+)
+
+// leaderKey là tên khóa (sau KeyPrefix) được các elector dùng để bầu chọn leader.
+const leaderKey = "__leader"
+
+// LeaderElector là gocron.Elector bầu chọn leader bằng một vòng lặp nền.
+//
+// Khi được gắn vào Manager qua WithLeaderElector, chỉ instance đang là leader mới chạy job.
+// Manager gọi Start khi scheduler khởi động và Stop khi scheduler dừng.
+type LeaderElector interface {
+	gocron.Elector
+
+	// Start thực hiện lần bầu chọn đầu tiên rồi tiếp tục bầu chọn trong nền.
+	// onChange được gọi mỗi khi instance hiện tại trở thành hoặc không còn là leader.
+	Start(onChange func(isLeader bool))
+
+	// Stop dừng bầu chọn và từ bỏ leadership nếu đang là leader.
+	Stop(ctx context.Context) error
+}
+
+// electorBackend là backend lưu trữ leadership cho leaderElector.
+type electorBackend interface {
+	// acquire trở thành leader với token nếu chưa có leader.
+	acquire(ctx context.Context, token string) (bool, error)
+
+	// renew gia hạn leadership nếu token vẫn là leader.
+	renew(ctx context.Context, token string) (bool, error)
+
+	// release từ bỏ leadership nếu token vẫn là leader.
+	release(ctx context.Context, token string) error
+}
+
+// leaderElector triển khai LeaderElector trên một electorBackend.
+type leaderElector struct {
+	backend    electorBackend
+	ttl        time.Duration
+	instanceID string
+	telemetry  *telemetry
+
+	mu          sync.Mutex
+	token       string
+	leader      bool
+	lastRenewed time.Time
+	onChange    func(isLeader bool)
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// newLeaderElector tạo leaderElector với thời hạn leadership ttl.
+func newLeaderElector(backend electorBackend, options RedisLockerOptionsTime) *leaderElector {
+	return &leaderElector{
+		backend:    backend,
+		ttl:        options.LockDuration,
+		instanceID: resolveInstanceID(options.InstanceID),
+	}
+}
+
+// NewRedisElector tạo LeaderElector sử dụng Redis, các instance dùng chung KeyPrefix sẽ bầu
+// chọn một leader duy nhất. LockDuration là thời hạn của leadership trước khi instance khác
+// có thể thay thế khi leader gặp sự cố.
+//
+// Example:
+//
+//	elector, err := scheduler.NewRedisElector(redisClient)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	manager.WithLeaderElector(elector)
+func NewRedisElector(client redis.UniversalClient, opts ...RedisLockerOptions) (LeaderElector, error) {
+	locker, err := NewRedisLocker(client, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	r := locker.(*redisLocker)
+	return newLeaderElector(&redisElectorBackend{locker: r, key: r.lockKey(leaderKey)}, r.options), nil
+}
+
+// NewMemoryElector tạo LeaderElector trong bộ nhớ. Các elector dùng chung store sẽ bầu chọn
+// một leader duy nhất, phù hợp để mô phỏng nhiều node trong test.
+func NewMemoryElector(store *MemoryLockStore, opts ...RedisLockerOptions) (LeaderElector, error) {
+	locker, err := NewMemoryLocker(store, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	m := locker.(*memoryLocker)
+	return newLeaderElector(&memoryElectorBackend{locker: m, key: m.options.KeyPrefix + leaderKey}, m.options), nil
+}
+
+// setTelemetry triển khai instrumented.
+func (e *leaderElector) setTelemetry(telemetry *telemetry) {
+	e.telemetry = telemetry
+}
+
+// IsLeader triển khai gocron.Elector, trả về nil nếu instance hiện tại là leader.
+func (e *leaderElector) IsLeader(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.leader && time.Since(e.lastRenewed) < e.ttl {
+		return nil
+	}
+	return ErrNotLeader
+}
+
+// Start thực hiện lần bầu chọn đầu tiên rồi tiếp tục bầu chọn trong nền.
+func (e *leaderElector) Start(onChange func(isLeader bool)) {
+	e.mu.Lock()
+	if e.cancel != nil {
+		e.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.done = make(chan struct{})
+	e.onChange = onChange
+	e.mu.Unlock()
+
+	// Bầu chọn ngay để job đầu tiên không bị bỏ qua trên leader
+	e.elect(ctx)
+
+	go e.run(ctx)
+}
+
+// run bầu chọn định kỳ sau mỗi 1/3 thời hạn leadership cho tới khi ctx bị hủy.
+func (e *leaderElector) run(ctx context.Context) {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.elect(ctx)
+		}
+	}
+}
+
+// elect gia hạn leadership nếu đang là leader, ngược lại thử trở thành leader.
+func (e *leaderElector) elect(ctx context.Context) {
+	e.mu.Lock()
+	leader, token, lastRenewed := e.leader, e.token, e.lastRenewed
+	e.mu.Unlock()
+
+	callCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if leader {
+		renewed, err := e.backend.renew(callCtx, token)
+		if err != nil {
+			e.telemetry.logger().Warn("scheduler: leadership renewal failed", slog.Any("error", err))
+		}
+		switch {
+		case err == nil && renewed:
+			e.setLeader(true, token)
+		case err == nil && !renewed:
+			// Leadership đã hết hạn và thuộc về instance khác
+			e.setLeader(false, "")
+		case time.Since(lastRenewed) >= e.ttl:
+			// Gia hạn thất bại cho tới khi leadership chắc chắn đã hết hạn
+			e.setLeader(false, "")
+		}
+		return
+	}
+
+	token, err := newLockToken(e.instanceID)
+	if err != nil {
+		e.telemetry.logger().Warn("scheduler: failed to create leadership token", slog.Any("error", err))
+		return
+	}
+	acquired, err := e.backend.acquire(callCtx, token)
+	if err != nil {
+		e.telemetry.logger().Warn("scheduler: leader election failed", slog.Any("error", err))
+		return
+	}
+	if acquired {
+		e.setLeader(true, token)
+	}
+}
+
+// setLeader cập nhật trạng thái leader và gọi onChange khi trạng thái thay đổi.
+func (e *leaderElector) setLeader(leader bool, token string) {
+	e.mu.Lock()
+	changed := e.leader != leader
+	e.leader = leader
+	e.token = token
+	if leader {
+		e.lastRenewed = time.Now()
+	}
+	onChange := e.onChange
+	e.mu.Unlock()
+
+	if changed && onChange != nil {
+		onChange(leader)
+	}
+}
+
+// Stop dừng bầu chọn và từ bỏ leadership nếu đang là leader.
+func (e *leaderElector) Stop(ctx context.Context) error {
+	e.mu.Lock()
+	cancel, done := e.cancel, e.done
+	e.cancel = nil
+	e.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+
+	e.mu.Lock()
+	leader, token := e.leader, e.token
+	e.mu.Unlock()
+
+	if !leader {
+		return nil
+	}
+
+	err := e.backend.release(ctx, token)
+	e.setLeader(false, "")
+	return err
+}
+
+// redisElectorBackend lưu leadership trong Redis với owner token.
+type redisElectorBackend struct {
+	locker *redisLocker
+	key    string
+}
+
+func (b *redisElectorBackend) acquire(ctx context.Context, token string) (bool, error) {
+	return b.locker.client.SetNX(ctx, b.key, token, b.locker.options.LockDuration).Result()
+}
+
+func (b *redisElectorBackend) renew(ctx context.Context, token string) (bool, error) {
+	ttl := b.locker.options.LockDuration.Milliseconds()
+	renewed, err := renewScript.Run(ctx, b.locker.client, []string{b.key}, token, ttl).Int()
+	return renewed == 1, err
+}
+
+func (b *redisElectorBackend) release(ctx context.Context, token string) error {
+	_, err := unlockScript.Run(ctx, b.locker.client, []string{b.key}, token).Int()
+	return err
+}
+
+// memoryElectorBackend lưu leadership trong MemoryLockStore.
+type memoryElectorBackend struct {
+	locker *memoryLocker
+	key    string
+}
+
+func (b *memoryElectorBackend) acquire(ctx context.Context, token string) (bool, error) {
+	return b.locker.store.acquire(b.key, token, b.locker.options.LockDuration), nil
+}
+
+func (b *memoryElectorBackend) renew(ctx context.Context, token string) (bool, error) {
+	return b.locker.store.renew(b.key, token, b.locker.options.LockDuration), nil
+}
+
+func (b *memoryElectorBackend) release(ctx context.Context, token string) error {
+	b.locker.store.release(b.key, token)
+	return nil
+}
+
+// Error constants cho leader election
+var (
+	// ErrNotLeader được trả về bởi IsLeader khi instance hiện tại không phải leader.
+	ErrNotLeader = errors.New("scheduler: this instance is not the leader")
+)
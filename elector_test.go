@@ -0,0 +1,175 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// testElectorOptions trả về tùy chọn với thời hạn leadership ngắn cho test.
+func testElectorOptions(instanceID string) RedisLockerOptions {
+	return RedisLockerOptions{
+		KeyPrefix:    "scheduler_lock:",
+		LockDuration: 1,
+		MaxRetries:   0,
+		RetryDelay:   10,
+		InstanceID:   instanceID,
+	}
+}
+
+// leadershipRecorder ghi lại các thay đổi leadership.
+type leadershipRecorder struct {
+	mu      sync.Mutex
+	changes []bool
+}
+
+func (r *leadershipRecorder) record(isLeader bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.changes = append(r.changes, isLeader)
+}
+
+func (r *leadershipRecorder) get() []bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]bool(nil), r.changes...)
+}
+
+func TestMemoryElectorSingleLeaderAndFailover(t *testing.T) {
+	store := NewMemoryLockStore()
+	electorA, _ := NewMemoryElector(store, testElectorOptions("node-a"))
+	electorB, _ := NewMemoryElector(store, testElectorOptions("node-b"))
+
+	var recorderA, recorderB leadershipRecorder
+	electorA.Start(recorderA.record)
+	electorB.Start(recorderB.record)
+	defer electorB.Stop(context.Background())
+
+	if err := electorA.IsLeader(context.Background()); err != nil {
+		t.Fatalf("Expected node-a to be leader, got %v", err)
+	}
+	if err := electorB.IsLeader(context.Background()); err != ErrNotLeader {
+		t.Fatalf("Expected ErrNotLeader for node-b, got %v", err)
+	}
+
+	// Leader dừng và từ bỏ leadership, node-b thay thế
+	if err := electorA.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop elector: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for electorB.IsLeader(context.Background()) != nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected node-b to become leader after node-a stopped")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if got := recorderA.get(); len(got) != 2 || !got[0] || got[1] {
+		t.Errorf("Expected node-a changes [true false], got %v", got)
+	}
+	if got := recorderB.get(); len(got) != 1 || !got[0] {
+		t.Errorf("Expected node-b changes [true], got %v", got)
+	}
+}
+
+func TestMemoryElectorLosesLeadershipAfterTakeover(t *testing.T) {
+	store := NewMemoryLockStore()
+	elector, _ := NewMemoryElector(store, testElectorOptions("node-a"))
+
+	var recorder leadershipRecorder
+	elector.Start(recorder.record)
+	defer elector.Stop(context.Background())
+
+	// Mô phỏng leadership hết hạn và bị node khác lấy
+	store.mu.Lock()
+	store.entries["scheduler_lock:"+leaderKey] = memoryLockEntry{token: "node-b:token", expiresAt: time.Now().Add(time.Minute)}
+	store.mu.Unlock()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for elector.IsLeader(context.Background()) == nil {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected leadership to be lost after takeover")
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if got := recorder.get(); len(got) != 2 || got[1] {
+		t.Errorf("Expected changes [true false], got %v", got)
+	}
+}
+
+func TestRedisElector(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	elector, err := NewRedisElector(client, testElectorOptions("node-a"))
+	if err != nil {
+		t.Fatalf("Failed to create redis elector: %v", err)
+	}
+
+	elector.Start(nil)
+	if err := elector.IsLeader(context.Background()); err != nil {
+		t.Fatalf("Expected to be leader, got %v", err)
+	}
+
+	value, err := server.Get("scheduler_lock:" + leaderKey)
+	if err != nil {
+		t.Fatalf("Expected leader key in redis: %v", err)
+	}
+	if len(value) < len("node-a:") || value[:len("node-a:")] != "node-a:" {
+		t.Errorf("Expected leader token to start with node-a:, got %q", value)
+	}
+
+	if err := elector.Stop(context.Background()); err != nil {
+		t.Fatalf("Failed to stop elector: %v", err)
+	}
+	if server.Exists("scheduler_lock:" + leaderKey) {
+		t.Error("Leader key should be released after Stop")
+	}
+	if err := elector.IsLeader(context.Background()); err != ErrNotLeader {
+		t.Errorf("Expected ErrNotLeader after Stop, got %v", err)
+	}
+}
+
+func TestManagerWithLeaderElector(t *testing.T) {
+	store := NewMemoryLockStore()
+	var runs int32
+	var acquired int32
+
+	for _, instanceID := range []string{"node-a", "node-b"} {
+		elector, err := NewMemoryElector(store, testElectorOptions(instanceID))
+		if err != nil {
+			t.Fatalf("Failed to create memory elector: %v", err)
+		}
+
+		scheduler := NewScheduler().WithLeaderElector(elector)
+		scheduler.OnEvent(func(event Event) {
+			if event.Type == EventLeadershipAcquired {
+				atomic.AddInt32(&acquired, 1)
+			}
+		})
+		if _, err := scheduler.Every(1).Hours().Do(func() {
+			atomic.AddInt32(&runs, 1)
+		}); err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+		scheduler.StartAsync()
+		defer scheduler.Stop()
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("Expected job to run only on the leader, got %d runs", got)
+	}
+	if got := atomic.LoadInt32(&acquired); got != 1 {
+		t.Errorf("Expected one EventLeadershipAcquired, got %d", got)
+	}
+}
@@ -0,0 +1,116 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType xác định loại sự kiện được scheduler phát ra trong vòng đời của job.
+type EventType string
+
+const (
+	// EventJobStarted được phát khi job bắt đầu chạy.
+	EventJobStarted EventType = "job_started"
+
+	// EventJobSucceeded được phát khi job kết thúc không có lỗi.
+	EventJobSucceeded EventType = "job_succeeded"
+
+	// EventJobFailed được phát khi job trả về lỗi.
+	EventJobFailed EventType = "job_failed"
+
+	// EventJobPanicked được phát khi job panic, trước EventJobFailed. Err là *PanicError
+	// chứa giá trị panic và stack trace.
+	EventJobPanicked EventType = "job_panicked"
+
+	// EventJobTimeout được phát khi lần chạy của job vượt quá thời gian chạy tối đa.
+	// Context của job bị hủy và khóa phân tán được giải phóng.
+	EventJobTimeout EventType = "job_timeout"
+
+	// EventJobRetrying được phát khi lần chạy thất bại sẽ được thử lại theo RetryPolicy.
+	EventJobRetrying EventType = "job_retrying"
+
+	// EventLockLost được phát khi khóa phân tán của job đang chạy bị mất
+	// (gia hạn thất bại hoặc khóa đã thuộc về instance khác). Context của job bị hủy.
+	EventLockLost EventType = "lock_lost"
+
+	// EventLeadershipAcquired được phát khi instance hiện tại trở thành leader.
+	EventLeadershipAcquired EventType = "leadership_acquired"
+
+	// EventLeadershipLost được phát khi instance hiện tại không còn là leader.
+	EventLeadershipLost EventType = "leadership_lost"
+
+	// EventJobsSynced được phát sau mỗi lần SyncJobs, Err chứa lỗi nếu đối chiếu thất bại.
+	EventJobsSynced EventType = "jobs_synced"
+
+	// EventJobPaused được phát khi job (JobName) hoặc tag (Tags) bị tạm dừng.
+	EventJobPaused EventType = "job_paused"
+
+	// EventJobResumed được phát khi job (JobName) hoặc tag (Tags) được tiếp tục.
+	EventJobResumed EventType = "job_resumed"
+
+	// EventJobRescheduled được phát khi lịch chạy của job được thay đổi qua Reschedule.
+	EventJobRescheduled EventType = "job_rescheduled"
+)
+
+// Event mô tả một sự kiện trong vòng đời của job.
+type Event struct {
+	// Type là loại sự kiện
+	Type EventType
+
+	// JobName là tên của job phát sinh sự kiện, trống với các sự kiện cấp scheduler
+	JobName string
+
+	// Tags là các tag của job tại thời điểm đăng ký
+	Tags []string
+
+	// Err là lỗi gắn với sự kiện (nếu có)
+	Err error
+
+	// Time là thời điểm sự kiện xảy ra
+	Time time.Time
+
+	// Duration là thời gian chạy của job (với EventJobSucceeded và EventJobFailed),
+	// hoặc timeout đã vượt quá (với EventJobTimeout)
+	Duration time.Duration
+
+	// Attempt là số thứ tự của lần chạy trong chuỗi thử lại, bắt đầu từ 1
+	Attempt int
+}
+
+// EventHandler là hàm xử lý sự kiện được đăng ký qua Manager.OnEvent.
+//
+// Handler được gọi đồng bộ trên goroutine phát sinh sự kiện, vì vậy không nên block lâu.
+type EventHandler func(event Event)
+
+// eventBus lưu trữ các EventHandler và phát sự kiện tới chúng.
+type eventBus struct {
+	mu       sync.RWMutex
+	handlers []EventHandler
+}
+
+// subscribe đăng ký thêm các handler.
+func (b *eventBus) subscribe(handlers ...EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, handler := range handlers {
+		if handler != nil {
+			b.handlers = append(b.handlers, handler)
+		}
+	}
+}
+
+// emit phát sự kiện tới tất cả các handler đã đăng ký.
+func (b *eventBus) emit(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.RLock()
+	handlers := make([]EventHandler, len(b.handlers))
+	copy(handlers, b.handlers)
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+import "testing"
+
+func TestEventBusEmit(t *testing.T) {
+	var bus eventBus
+	var received []Event
+
+	bus.subscribe(func(event Event) { received = append(received, event) }, nil)
+	bus.subscribe(func(event Event) { received = append(received, event) })
+
+	bus.emit(Event{Type: EventLockLost, JobName: "job"})
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 handler calls, got %d", len(received))
+	}
+	for _, event := range received {
+		if event.JobName != "job" || event.Type != EventLockLost {
+			t.Errorf("Unexpected event %+v", event)
+		}
+		if event.Time.IsZero() {
+			t.Error("Expected emit to set event time")
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"go.fork.vn/di"
+)
+
+// Các driver có sẵn cho HistoryStore.
+const (
+	// HistoryDriverMemory lưu lịch sử trong bộ nhớ của process (ring buffer theo job)
+	HistoryDriverMemory = "memory"
+
+	// HistoryDriverRedis lưu lịch sử trong Redis
+	HistoryDriverRedis = "redis"
+
+	// HistoryDriverPostgres lưu lịch sử trong PostgreSQL
+	HistoryDriverPostgres = "postgres"
+
+	// HistoryDriverMySQL lưu lịch sử trong MySQL
+	HistoryDriverMySQL = "mysql"
+
+	// HistoryDriverSQLite lưu lịch sử trong SQLite
+	HistoryDriverSQLite = "sqlite"
+)
+
+// DefaultHistoryMaxEntries là số lần chạy tối đa được giữ cho mỗi job bởi HistoryStore
+// trong bộ nhớ và Redis khi không chỉ định.
+const DefaultHistoryMaxEntries = 100
+
+// DefaultHistoryPageSize là số lần chạy trả về bởi Manager.History khi limit không dương.
+const DefaultHistoryPageSize = 20
+
+// JobRun ghi nhận một lần chạy của job, tính cả các lần thử lại.
+type JobRun struct {
+	// JobName là tên của job
+	JobName string `json:"job_name"`
+
+	// Tags là các tag của job tại thời điểm đăng ký
+	Tags []string `json:"tags,omitempty"`
+
+	// ScheduledAt là thời điểm lần chạy được lên lịch
+	ScheduledAt time.Time `json:"scheduled_at"`
+
+	// StartedAt là thời điểm lần chạy bắt đầu, sau khi lấy được khóa phân tán
+	StartedAt time.Time `json:"started_at"`
+
+	// FinishedAt là thời điểm lần chạy kết thúc hoặc hết thời gian chạy
+	FinishedAt time.Time `json:"finished_at"`
+
+	// InstanceID định danh instance đã chạy job
+	InstanceID string `json:"instance_id"`
+
+	// Outcome là kết quả của lần chạy: OutcomeSucceeded, OutcomeFailed hoặc OutcomeTimeout
+	Outcome string `json:"outcome"`
+
+	// Error là thông báo lỗi của lần chạy, trống nếu lần chạy thành công
+	Error string `json:"error,omitempty"`
+
+	// Attempt là số thứ tự của lần thử cuối cùng, bắt đầu từ 1
+	Attempt int `json:"attempt"`
+}
+
+// Duration trả về thời gian chạy.
+func (r JobRun) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// HistoryStore lưu lịch sử các lần chạy job.
+//
+// Manager gọi Record đồng bộ sau mỗi lần chạy, vì vậy Record phải an toàn khi gọi đồng thời.
+// Các lần chạy bị bỏ qua vì không lấy được khóa phân tán không được ghi nhận.
+type HistoryStore interface {
+	// Record lưu một lần chạy.
+	Record(ctx context.Context, run JobRun) error
+
+	// List trả về tối đa limit lần chạy của job, mới nhất trước, bỏ qua offset lần chạy đầu tiên.
+	List(ctx context.Context, job string, limit, offset int) ([]JobRun, error)
+}
+
+// recordRun lưu lần chạy vào HistoryStore của Manager (nếu có). Lỗi khi lưu được ghi log.
+func (m *manager) recordRun(def *jobDefinition, scheduledAt, startedAt time.Time, attempt int, err error) {
+	if m.history == nil {
+		return
+	}
+
+	run := JobRun{
+		JobName:     def.name,
+		Tags:        def.tags,
+		ScheduledAt: scheduledAt,
+		StartedAt:   startedAt,
+		FinishedAt:  time.Now(),
+		InstanceID:  m.instanceID,
+		Outcome:     runOutcome(err),
+		Attempt:     attempt,
+	}
+	if err != nil {
+		run.Error = err.Error()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := m.history.Record(ctx, run); err != nil {
+		m.telemetry.logger().Warn("scheduler: failed to record job run", slog.String("job", def.name), slog.Any("error", err))
+	}
+}
+
+// runOutcome trả về kết quả của lần chạy theo lỗi trả về.
+func runOutcome(err error) string {
+	switch {
+	case errors.Is(err, ErrJobTimeout):
+		return OutcomeTimeout
+	case err != nil:
+		return OutcomeFailed
+	}
+	return OutcomeSucceeded
+}
+
+// Error constants cho lịch sử chạy job
+var (
+	// ErrHistoryNotConfigured được trả về bởi Manager.History khi chưa thiết lập HistoryStore.
+	ErrHistoryNotConfigured = errors.New("scheduler: job history is not configured")
+
+	// ErrUnsupportedHistoryDriver được trả về khi driver của HistoryStore không được hỗ trợ.
+	ErrUnsupportedHistoryDriver = errors.New("scheduler: unsupported history driver")
+
+	// ErrInvalidHistoryMaxEntries được trả về khi số lần chạy tối đa được giữ cho mỗi job âm.
+	ErrInvalidHistoryMaxEntries = errors.New("scheduler: invalid history max entries")
+)
+
+// newHistoryStoreFromContainer tạo HistoryStore theo HistoryConfig, với *sql.DB hoặc Redis client
+// lấy từ DI container.
+func newHistoryStoreFromContainer(container di.Container, cfg HistoryConfig) (HistoryStore, error) {
+	switch driver := cfg.DriverName(); driver {
+	case HistoryDriverMemory:
+		return NewMemoryHistoryStore(cfg.MaxEntries), nil
+	case HistoryDriverRedis:
+		client, err := redisClientFromContainer(container, cfg.RedisClient)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedisHistoryStore(client, cfg.KeyPrefix, cfg.MaxEntries)
+	case HistoryDriverPostgres, HistoryDriverMySQL, HistoryDriverSQLite:
+		db, err := sqlDBFromContainer(container, cfg.Database)
+		if err != nil {
+			return nil, err
+		}
+		return NewSQLHistoryStore(db, driver)
+	default:
+		return nil, ErrUnsupportedHistoryDriver
+	}
+}
@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// failingHistoryStore là HistoryStore luôn trả về lỗi khi ghi.
+type failingHistoryStore struct {
+	HistoryStore
+}
+
+func (failingHistoryStore) Record(ctx context.Context, run JobRun) error {
+	return errors.New("store unavailable")
+}
+
+// waitForRuns chờ tới khi store có ít nhất n lần chạy của job.
+func waitForRuns(t *testing.T, scheduler Manager, job string, n int) []JobRun {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		runs, err := scheduler.History(context.Background(), job, 10, 0)
+		if err != nil {
+			t.Fatalf("Failed to list history: %v", err)
+		}
+		if len(runs) >= n {
+			return runs
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected %d runs of %s to be recorded", n, job)
+	return nil
+}
+
+func TestManagerRecordsJobRuns(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Options.InstanceID = "node-a"
+	scheduler := NewScheduler(cfg).WithHistory(NewMemoryHistoryStore(10))
+
+	var mu sync.Mutex
+	calls := 0
+	_, err := scheduler.Every(1).Hours().Tag("reports").Name("report").
+		Retry(RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}).
+		DoContext(func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			if calls == 1 {
+				return errors.New("temporary")
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if _, err := scheduler.Every(1).Hours().Name("failing").DoContext(func(ctx context.Context) error {
+		return errors.New("boom")
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if _, err := scheduler.Every(1).Hours().Name("slow").Timeout(20 * time.Millisecond).DoContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	run := waitForRuns(t, scheduler, "report", 1)[0]
+	if run.Outcome != OutcomeSucceeded || run.Attempt != 2 || run.Error != "" {
+		t.Errorf("Unexpected run: %+v", run)
+	}
+	if run.InstanceID != "node-a" || len(run.Tags) != 1 || run.Tags[0] != "reports" {
+		t.Errorf("Unexpected run metadata: %+v", run)
+	}
+	if run.ScheduledAt.IsZero() || run.ScheduledAt.After(run.StartedAt) || run.FinishedAt.Before(run.StartedAt) {
+		t.Errorf("Unexpected run times: scheduled %v, started %v, finished %v", run.ScheduledAt, run.StartedAt, run.FinishedAt)
+	}
+
+	run = waitForRuns(t, scheduler, "failing", 1)[0]
+	if run.Outcome != OutcomeFailed || run.Error != "boom" || run.Attempt != 1 {
+		t.Errorf("Unexpected failed run: %+v", run)
+	}
+
+	run = waitForRuns(t, scheduler, "slow", 1)[0]
+	if run.Outcome != OutcomeTimeout || run.Error != ErrJobTimeout.Error() {
+		t.Errorf("Unexpected timed out run: %+v", run)
+	}
+}
+
+func TestManagerHistory(t *testing.T) {
+	scheduler := NewScheduler()
+	if _, err := scheduler.History(context.Background(), "report", 10, 0); !errors.Is(err, ErrHistoryNotConfigured) {
+		t.Errorf("Expected ErrHistoryNotConfigured, got %v", err)
+	}
+
+	store := NewMemoryHistoryStore(50)
+	recordTestRuns(t, store, "report", 25)
+	scheduler.WithHistory(store)
+
+	runs, err := scheduler.History(context.Background(), "report", 0, -1)
+	if err != nil {
+		t.Fatalf("Failed to list history: %v", err)
+	}
+	if len(runs) != DefaultHistoryPageSize || runs[0].Attempt != 25 {
+		t.Errorf("Expected default page of newest runs, got %d runs", len(runs))
+	}
+
+	runs, _ = scheduler.History(context.Background(), "report", 10, 20)
+	if got := attempts(runs); got != "[5 4 3 2 1]" {
+		t.Errorf("Expected last page, got %s", got)
+	}
+}
+
+func TestManagerLogsHistoryFailures(t *testing.T) {
+	logger, buf := newTestLogger()
+	scheduler := NewScheduler().WithLogger(logger).WithHistory(failingHistoryStore{})
+
+	done := make(chan struct{})
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobSucceeded {
+			close(done)
+		}
+	})
+	if _, err := scheduler.Every(1).Hours().Name("report").DoContext(func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to run")
+	}
+	scheduler.Stop()
+
+	if out := buf.String(); !strings.Contains(out, "failed to record job run") || !strings.Contains(out, "store unavailable") {
+		t.Errorf("Expected history failure to be logged, got %q", out)
+	}
+}
@@ -0,0 +1,350 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// configuredJob là job được lên lịch từ JobConfig qua ScheduleJob hoặc SyncJobs.
+type configuredJob struct {
+	config JobConfig
+	job    *gocron.Job
+}
+
+// ScheduleJob lên lịch job theo JobConfig.
+//
+// Cron 6 trường được hiểu là có giây. Job với Lock = false không lấy khóa phân tán ở chế độ
+// per_job_lock; Timeout giới hạn thời gian chạy của job như Manager.Timeout. Job được quản lý theo
+// Name, vì vậy SyncJobs có thể cập nhật hoặc xóa job sau này.
+func (m *manager) ScheduleJob(job JobConfig) (*gocron.Job, error) {
+	if err := job.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.syncMu.Lock()
+	defer m.syncMu.Unlock()
+
+	if _, exists := m.configured[job.Name]; exists {
+		return nil, fmt.Errorf("%w (job %q)", ErrDuplicateJobName, job.Name)
+	}
+	if err := m.applyJobConfigs([]JobConfig{job}, nil, nil); err != nil {
+		return nil, err
+	}
+	return m.configured[job.Name].job, nil
+}
+
+// configuredBuild là job của gocron được tạo cho một JobConfig bởi applyJobConfigs.
+type configuredBuild struct {
+	config   JobConfig
+	schedule JobSchedule // Lịch chạy của job: lịch chạy trong config hoặc lịch chạy đổi qua Reschedule
+	entry    *jobEntry
+	def      *jobDefinition
+	job      *gocron.Job
+	old      *gocron.Job // Job được thay thế, nil nếu job mới được thêm
+	carried  []string    // Tag được chuyển từ job bị xóa hoặc thay thế
+}
+
+// applyJobConfigs thêm các job added, thay các job changed và xóa các job removed (theo tên)
+// khai báo trong cấu hình mà không dùng fluent chain của Manager.
+//
+// Job thay đổi giữ nguyên entry như Reschedule, vì vậy lần chạy đang diễn ra, trạng thái chạy
+// và khóa singleton của job được giữ; job mới của gocron chờ tới lịch chạy kế tiếp. Mọi job mới
+// được tạo trước khi job cũ nào bị xóa: nếu không tạo được một job, các job đã tạo bị xóa và
+// không có thay đổi nào được áp dụng. Caller phải giữ m.syncMu.
+func (m *manager) applyJobConfigs(added, changed []JobConfig, removed []string) error {
+	m.entriesMu.Lock()
+	defer m.entriesMu.Unlock()
+
+	// Tag của job cũ vẫn được đăng ký trong TagsUnique của scheduler, vì vậy tag mà job mới dùng
+	// lại chỉ được gán sau khi job cũ bị xóa
+	held := make(map[string]bool)
+	for _, name := range removed {
+		for _, tag := range m.configured[name].config.Tags {
+			held[tag] = true
+		}
+	}
+	for _, job := range changed {
+		for _, tag := range m.configured[job.Name].config.Tags {
+			held[tag] = true
+		}
+	}
+
+	builds := make([]configuredBuild, 0, len(added)+len(changed))
+	for _, job := range added {
+		builds = append(builds, configuredBuild{config: job, schedule: job.Schedule()})
+	}
+	for _, job := range changed {
+		current := m.configured[job.Name]
+		// Lịch chạy đổi qua Reschedule được giữ khi lịch chạy trong cấu hình không thay đổi
+		schedule, ok := m.overrides[job.Name]
+		if !ok || job.Schedule() != current.config.Schedule() {
+			schedule = job.Schedule()
+		}
+		builds = append(builds, configuredBuild{config: job, schedule: schedule, entry: m.entries[current.job], old: current.job})
+	}
+
+	carried := make(map[string]bool)
+	for i := range builds {
+		b := &builds[i]
+		var fresh []string
+		for _, tag := range b.config.Tags {
+			if held[tag] {
+				b.carried = append(b.carried, tag)
+				carried[tag] = true
+			} else {
+				fresh = append(fresh, tag)
+			}
+		}
+
+		b.def = b.config.definition()
+		b.def.schedule = b.schedule.describe()
+		if b.entry == nil {
+			b.entry = &jobEntry{}
+			b.entry.fn = m.configuredFunc(b.entry)
+			b.entry.def.Store(b.def)
+		}
+
+		var err error
+		b.job, err = m.newJob(b.entry, b.config, b.schedule, fresh, b.old != nil)
+		if err != nil {
+			// Hủy các job đã tạo và khôi phục khóa của job cũ
+			for _, built := range builds[:i+1] {
+				if built.job != nil {
+					m.Scheduler.RemoveByReference(built.job)
+				}
+				current, exists := m.configured[built.config.Name]
+				m.locks.exempt(built.config.Name, exists && !current.config.UsesLock())
+			}
+			return fmt.Errorf("%w (job %q)", err, b.config.Name)
+		}
+	}
+
+	// Xóa các job cũ; tag được chuyển sang job mới được bỏ khỏi job cũ trước khi xóa để vẫn được
+	// giữ trong TagsUnique của scheduler
+	remove := func(old *gocron.Job) {
+		for _, tag := range old.Tags() {
+			if carried[tag] {
+				old.Untag(tag)
+			}
+		}
+		m.Scheduler.RemoveByReference(old)
+		delete(m.entries, old)
+	}
+	for _, name := range removed {
+		remove(m.configured[name].job)
+		m.locks.exempt(name, false)
+		delete(m.configured, name)
+		delete(m.overrides, name)
+	}
+	for _, b := range builds {
+		if b.old != nil {
+			remove(b.old)
+			b.entry.def.Store(b.def)
+		}
+		if len(b.carried) > 0 {
+			b.job.Tag(b.carried...)
+		}
+		b.entry.job.Store(b.job)
+		m.entries[b.job] = b.entry
+		m.configured[b.config.Name] = configuredJob{config: b.config, job: b.job}
+		if b.schedule == b.config.Schedule() {
+			delete(m.overrides, b.config.Name)
+		}
+	}
+	return nil
+}
+
+// newJob tạo job của gocron chạy entry theo lịch chạy schedule mà không dùng fluent chain của
+// Manager (m.pending), với các tag trong tags. Nếu waitForSchedule là true, job chờ tới lịch
+// chạy kế tiếp thay vì chạy ngay. Job chưa được ghi nhận vào m.entries; caller phải giữ
+// m.entriesMu.
+func (m *manager) newJob(entry *jobEntry, job JobConfig, schedule JobSchedule, tags []string, waitForSchedule bool) (*gocron.Job, error) {
+	// Khóa của job được thiết lập trước vì lần chạy đầu tiên có thể bắt đầu trước khi Do trả về
+	m.locks.exempt(job.Name, !job.UsesLock())
+
+	schedule.apply(m.Scheduler)
+	m.Scheduler.Name(job.Name)
+	if len(tags) > 0 {
+		m.Scheduler.Tag(tags...)
+	}
+	if waitForSchedule {
+		m.Scheduler.WaitForSchedule()
+	}
+	return m.Scheduler.Do(func() error {
+		return m.runScheduled(entry)
+	})
+}
+
+// configuredFunc trả về hàm của job khai báo trong cấu hình: handler được tra cứu trong Registry
+// theo tên handler hiện tại của entry khi job chạy, vì vậy SyncJobs có thể đổi handler của job.
+func (m *manager) configuredFunc(entry *jobEntry) JobFunc {
+	return func(ctx context.Context) error {
+		name := entry.def.Load().handler
+		handler, ok := m.registry.Handler(name)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrJobHandlerNotFound, name)
+		}
+		return handler(ctx)
+	}
+}
+
+// definition trả về định nghĩa job theo JobConfig.
+func (j JobConfig) definition() *jobDefinition {
+	def := &jobDefinition{
+		name:      j.Name,
+		tags:      append([]string(nil), j.Tags...),
+		schedule:  j.Schedule().describe(),
+		handler:   j.HandlerName(),
+		timeout:   time.Duration(j.Timeout) * time.Second,
+		singleton: j.Singleton,
+	}
+	if j.Retry != nil {
+		def.retry = j.Retry.ToRetryPolicy()
+	}
+	return def
+}
+
+// apply bắt đầu cấu hình job với lịch chạy s trong fluent chain của scheduler.
+// Cron 6 trường được hiểu là có giây.
+func (s JobSchedule) apply(scheduler *gocron.Scheduler) {
+	switch {
+	case s.Cron != "":
+		if len(strings.Fields(s.Cron)) == 6 {
+			scheduler.CronWithSeconds(s.Cron)
+		} else {
+			scheduler.Cron(s.Cron)
+		}
+	case s.Interval != "":
+		interval, _ := time.ParseDuration(s.Interval)
+		scheduler.Every(interval)
+	default:
+		scheduler.Every(1).Days().At(s.At)
+	}
+}
+
+// describe trả về mô tả lịch chạy s như JobStatus.Schedule.
+func (s JobSchedule) describe() string {
+	switch {
+	case s.Cron != "":
+		return "cron " + s.Cron
+	case s.Interval != "":
+		interval, _ := time.ParseDuration(s.Interval)
+		return fmt.Sprintf("every %v", interval)
+	default:
+		return "every 1 days at " + s.At
+	}
+}
+
+// check kiểm tra lịch chạy s với một gocron.Scheduler tạm theo location, ví dụ biểu thức cron
+// hoặc At sai định dạng, để job đang chạy không bị xóa khi lịch chạy mới không hợp lệ.
+func (s JobSchedule) check(location *time.Location) error {
+	probe := gocron.NewScheduler(location)
+	s.apply(probe)
+	if _, err := probe.Do(func() {}); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSchedule, err)
+	}
+	return nil
+}
+
+// SyncJobs đối chiếu các job được lên lịch từ JobConfig với danh sách jobs.
+//
+// Job mới được thêm, job không còn trong danh sách hoặc có enabled: false bị xóa, job có
+// cấu hình thay đổi được lên lịch lại và chờ tới lịch chạy kế tiếp. Job không thay đổi được
+// giữ nguyên. Các lần chạy đang diễn ra không bị gián đoạn: job bị xóa hoặc lên lịch lại vẫn
+// chạy hết lần hiện tại, và lần chạy của job singleton được lên lịch lại không chạy chồng với
+// lần chạy đó. Job đăng ký trực tiếp qua Do, DoContext hoặc DoHandler không bị ảnh hưởng.
+//
+// Nếu jobs không hợp lệ hoặc không lên lịch được một job, không có thay đổi nào được áp dụng.
+// Kết quả được phát qua EventJobsSynced.
+func (m *manager) SyncJobs(jobs []JobConfig) error {
+	err := m.syncJobs(jobs)
+	m.events.emit(Event{Type: EventJobsSynced, Err: err})
+	return err
+}
+
+// syncJobs thực hiện đối chiếu cho SyncJobs.
+func (m *manager) syncJobs(jobs []JobConfig) error {
+	desired := make(map[string]JobConfig, len(jobs))
+	for _, job := range jobs {
+		if err := job.Validate(); err != nil {
+			return fmt.Errorf("%w (job %q)", err, job.Name)
+		}
+		if err := job.Schedule().check(m.Scheduler.Location()); err != nil {
+			return fmt.Errorf("%w (job %q)", err, job.Name)
+		}
+		if _, exists := desired[job.Name]; exists {
+			return fmt.Errorf("%w (job %q)", ErrDuplicateJobName, job.Name)
+		}
+		desired[job.Name] = job
+	}
+
+	m.syncMu.Lock()
+	defer m.syncMu.Unlock()
+
+	scheduled := make(map[*gocron.Job]bool)
+	for _, job := range m.Scheduler.Jobs() {
+		scheduled[job] = true
+	}
+
+	// Các job đã bị xóa hoặc bị tắt
+	var removed []string
+	for name, current := range m.configured {
+		if !scheduled[current.job] {
+			// Job đã bị xóa khỏi scheduler (ví dụ qua RemoveByTag)
+			m.locks.exempt(name, false)
+			delete(m.configured, name)
+			delete(m.overrides, name)
+			continue
+		}
+		if want, ok := desired[name]; !ok || !want.IsEnabled() {
+			removed = append(removed, name)
+		}
+	}
+
+	// Các job mới và các job có cấu hình thay đổi
+	var added, changed []JobConfig
+	for _, job := range jobs {
+		if !job.IsEnabled() {
+			continue
+		}
+		current, exists := m.configured[job.Name]
+		if !exists {
+			added = append(added, job)
+			continue
+		}
+		if override, ok := m.overrides[job.Name]; ok {
+			// Lịch chạy đổi qua Reschedule đã được ghi vào cấu hình: job không thay đổi
+			effective := current.config
+			effective.Cron, effective.Interval, effective.At = override.Cron, override.Interval, override.At
+			if reflect.DeepEqual(job, effective) {
+				m.configured[job.Name] = configuredJob{config: job, job: current.job}
+				delete(m.overrides, job.Name)
+				continue
+			}
+		}
+		if !reflect.DeepEqual(job, current.config) {
+			changed = append(changed, job)
+		}
+	}
+	return m.applyJobConfigs(added, changed, removed)
+}
+
+// DoHandler đặt handler đã đăng ký theo tên để thực thi cho công việc.
+func (m *manager) DoHandler(name string) (*gocron.Job, error) {
+	if m.pending.name == "" {
+		m.Name(name)
+	}
+
+	return m.DoContext(func(ctx context.Context) error {
+		handler, ok := m.registry.Handler(name)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrJobHandlerNotFound, name)
+		}
+		return handler(ctx)
+	})
+}
@@ -0,0 +1,339 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/stretchr/testify/assert"
+)
+
+// jobNames trả về tên các job trong scheduler.
+func jobNames(m Manager) map[string]*gocron.Job {
+	jobs := make(map[string]*gocron.Job)
+	for _, job := range m.GetScheduler().Jobs() {
+		jobs[job.GetName()] = job
+	}
+	return jobs
+}
+
+func TestScheduleJobRejectsDuplicateName(t *testing.T) {
+	scheduler := NewScheduler()
+
+	_, err := scheduler.ScheduleJob(JobConfig{Name: "sync", Interval: "5m"})
+	assert.NoError(t, err)
+
+	_, err = scheduler.ScheduleJob(JobConfig{Name: "sync", Interval: "10m"})
+	assert.ErrorIs(t, err, ErrDuplicateJobName)
+}
+
+func TestSyncJobsReconcilesConfiguredJobs(t *testing.T) {
+	scheduler := NewScheduler()
+
+	// Job đăng ký từ code không bị SyncJobs quản lý
+	_, err := scheduler.Every(1).Hours().Name("code").DoContext(func(ctx context.Context) error { return nil })
+	assert.NoError(t, err)
+
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{
+		{Name: "keep", Interval: "1h"},
+		{Name: "change", Interval: "1h"},
+		{Name: "remove", Interval: "1h"},
+		{Name: "disable", Interval: "1h"},
+	}))
+
+	before := jobNames(scheduler)
+	assert.Len(t, before, 5)
+
+	disabled := false
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{
+		{Name: "keep", Interval: "1h"},
+		{Name: "change", Cron: "*/5 * * * *"},
+		{Name: "disable", Interval: "1h", Enabled: &disabled},
+		{Name: "add", Interval: "30m"},
+	}))
+
+	after := jobNames(scheduler)
+	assert.Len(t, after, 4)
+	assert.Contains(t, after, "code")
+	assert.Contains(t, after, "add")
+	assert.NotContains(t, after, "remove")
+	assert.NotContains(t, after, "disable")
+
+	// Job không thay đổi được giữ nguyên, job thay đổi được lên lịch lại
+	assert.Same(t, before["keep"], after["keep"])
+	assert.NotSame(t, before["change"], after["change"])
+	assert.Equal(t, before["code"], after["code"])
+
+	// Bật lại job đã tắt
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{
+		{Name: "keep", Interval: "1h"},
+		{Name: "change", Cron: "*/5 * * * *"},
+		{Name: "disable", Interval: "1h"},
+		{Name: "add", Interval: "30m"},
+	}))
+	assert.Contains(t, jobNames(scheduler), "disable")
+}
+
+func TestSyncJobsInvalidConfigKeepsJobs(t *testing.T) {
+	scheduler := NewScheduler()
+
+	var synced []Event
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobsSynced {
+			synced = append(synced, event)
+		}
+	})
+
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{{Name: "sync", Interval: "1h"}}))
+
+	err := scheduler.SyncJobs([]JobConfig{{Name: "sync", Interval: "often"}})
+	assert.ErrorIs(t, err, ErrInvalidJobInterval)
+	assert.Contains(t, jobNames(scheduler), "sync")
+
+	if assert.Len(t, synced, 2) {
+		assert.NoError(t, synced[0].Err)
+		assert.ErrorIs(t, synced[1].Err, ErrInvalidJobInterval)
+	}
+}
+
+func TestSyncJobsInvalidCronKeepsJobs(t *testing.T) {
+	scheduler := NewScheduler()
+
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{
+		{Name: "sync", Interval: "1h"},
+		{Name: "cleanup", Cron: "0 3 * * *"},
+	}))
+
+	// Biểu thức cron sai không xóa job nào, kể cả job có cấu hình hợp lệ đã thay đổi
+	err := scheduler.SyncJobs([]JobConfig{
+		{Name: "sync", Interval: "2h"},
+		{Name: "cleanup", Cron: "0 3 * *"},
+	})
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+	assert.Len(t, scheduler.GetScheduler().Jobs(), 2)
+
+	schedules := map[string]string{}
+	for _, job := range scheduler.Jobs() {
+		schedules[job.Name] = job.Schedule
+	}
+	assert.Equal(t, map[string]string{"cleanup": "cron 0 3 * * *", "sync": "every 1h0m0s"}, schedules)
+}
+
+func TestSyncJobsDoesNotInterruptRunningJob(t *testing.T) {
+	scheduler := NewScheduler()
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	finished := make(chan error, 1)
+	_ = scheduler.Registry().Register("slow", func(ctx context.Context) error {
+		started <- struct{}{}
+		select {
+		case <-release:
+			finished <- nil
+		case <-ctx.Done():
+			finished <- ctx.Err()
+		}
+		return nil
+	})
+
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{{Name: "slow", Interval: "1h"}}))
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to start")
+	}
+
+	// Lên lịch lại job đang chạy: lần chạy hiện tại tiếp tục, lịch mới chờ tới lần chạy kế tiếp
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{{Name: "slow", Interval: "2h"}}))
+	close(release)
+
+	select {
+	case err := <-finished:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected running job to finish")
+	}
+
+	select {
+	case <-started:
+		t.Error("Rescheduled job should wait for its next scheduled run")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestSyncJobsRestoresRemovedJobs(t *testing.T) {
+	scheduler := NewScheduler()
+	jobs := []JobConfig{{Name: "sync", Interval: "1h", Tags: []string{"orders"}}}
+
+	assert.NoError(t, scheduler.SyncJobs(jobs))
+	scheduler.Clear()
+
+	assert.NoError(t, scheduler.SyncJobs(jobs))
+	assert.Contains(t, jobNames(scheduler), "sync")
+
+	// Job bị xóa trực tiếp khỏi scheduler được lên lịch lại
+	assert.NoError(t, scheduler.RemoveByTag("orders"))
+	assert.NoError(t, scheduler.SyncJobs(jobs))
+	assert.Contains(t, jobNames(scheduler), "sync")
+
+	err := scheduler.SyncJobs(append(jobs, jobs[0]))
+	if !errors.Is(err, ErrDuplicateJobName) {
+		t.Errorf("Expected ErrDuplicateJobName, got %v", err)
+	}
+}
+
+func TestScheduleJobSingletonUsesDistributedLock(t *testing.T) {
+	store := NewMemoryLockStore()
+
+	var running, maxRunning, total atomic.Int32
+	work := func(ctx context.Context) error {
+		n := running.Add(1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(30 * time.Millisecond)
+		running.Add(-1)
+		total.Add(1)
+		return nil
+	}
+
+	for _, node := range []string{"node-a", "node-b"} {
+		locker, err := NewMemoryLocker(store, testMemoryLockerOptions(node))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		scheduler := NewScheduler().WithDistributedLocker(locker)
+		defer scheduler.Stop()
+
+		if err := scheduler.Registry().Register("work", work); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if _, err := scheduler.ScheduleJob(JobConfig{Name: "work", Interval: "10ms", Singleton: true}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		scheduler.StartAsync()
+	}
+
+	time.Sleep(300 * time.Millisecond)
+	assert.Positive(t, total.Load())
+	assert.Equal(t, int32(1), maxRunning.Load(), "singleton job must not run on both nodes at once")
+}
+
+func TestSyncJobsKeepsSingletonRunExclusive(t *testing.T) {
+	scheduler := NewScheduler()
+
+	var running, maxRunning atomic.Int32
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	_ = scheduler.Registry().Register("slow", func(ctx context.Context) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			current := maxRunning.Load()
+			if n <= current || maxRunning.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+
+	job := JobConfig{Name: "slow", Interval: "1h", Singleton: true}
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{job}))
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to start")
+	}
+
+	// Job được lên lịch lại trong lúc đang chạy vẫn giữ trạng thái và khóa singleton của lần chạy đó
+	job.Interval = "2h"
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{job}))
+	jobs := scheduler.Jobs()
+	if len(jobs) != 1 {
+		t.Fatalf("Expected 1 job, got %d", len(jobs))
+	}
+	assert.True(t, jobs[0].Running)
+	assert.False(t, jobs[0].LastRun.IsZero())
+	assert.Equal(t, "every 2h0m0s", jobs[0].Schedule)
+
+	handle, err := scheduler.RunNow("slow")
+	if err != nil {
+		t.Fatalf("Failed to run job: %v", err)
+	}
+	select {
+	case <-started:
+		t.Fatal("Expected run to wait for the in-flight singleton run")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	assert.NoError(t, handle.Wait(ctx))
+	assert.Equal(t, int32(1), maxRunning.Load())
+}
+
+func TestSyncJobsFailureKeepsJobs(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TagsUnique = true
+	scheduler := NewScheduler(cfg)
+
+	jobs := []JobConfig{{Name: "sync", Interval: "1h", Tags: []string{"orders"}}}
+	assert.NoError(t, scheduler.SyncJobs(jobs))
+	original := jobNames(scheduler)["sync"]
+
+	// Tag "billing" đã thuộc về job khác: job mới không được lên lịch và job đã thay đổi được giữ nguyên
+	if _, err := scheduler.Every(1).Hours().Name("invoice").Tag("billing").Do(func() {}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	changed := []JobConfig{
+		{Name: "sync", Interval: "2h", Tags: []string{"orders"}},
+		{Name: "report", Interval: "1h", Tags: []string{"billing"}},
+	}
+	assert.Error(t, scheduler.SyncJobs(changed))
+	assert.Same(t, original, jobNames(scheduler)["sync"])
+	assert.NotContains(t, jobNames(scheduler), "report")
+
+	// Tag của job được lên lịch lại vẫn là tag duy nhất của job
+	assert.NoError(t, scheduler.SyncJobs(changed[:1]))
+	assert.NotSame(t, original, jobNames(scheduler)["sync"])
+	assert.Equal(t, []string{"orders"}, jobNames(scheduler)["sync"].Tags())
+	_, err := scheduler.Every(1).Hours().Name("other").Tag("orders").Do(func() {})
+	assert.Error(t, err)
+}
+
+func TestSyncJobsDoesNotConsumeFluentChain(t *testing.T) {
+	scheduler := NewScheduler()
+
+	// SyncJobs chạy giữa fluent chain của ứng dụng (ví dụ khi cấu hình được nạp lại)
+	scheduler.SingletonMode().Timeout(time.Minute)
+	assert.NoError(t, scheduler.SyncJobs([]JobConfig{{Name: "sync", Interval: "1h"}}))
+	if _, err := scheduler.Every(1).Hours().Name("app").DoContext(func(ctx context.Context) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	m := scheduler.(*manager)
+	app := m.entriesByName("app")[0].def.Load()
+	assert.True(t, app.singleton)
+	assert.Equal(t, time.Minute, app.timeout)
+	configured := m.entriesByName("sync")[0].def.Load()
+	assert.False(t, configured.singleton)
+	assert.Zero(t, configured.timeout)
+}
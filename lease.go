@@ -0,0 +1,144 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// lease quản lý vòng đời của một khóa đã lấy được: tự động gia hạn trước khi hết hạn
+// và báo hiệu qua LockLostNotifier khi khóa bị mất.
+//
+// lease được dùng chung bởi các locker trong package (Redis, memory, ...), mỗi locker chỉ
+// cần cung cấp hàm renew cho backend của mình.
+type lease struct {
+	duration  time.Duration
+	renewFn   func(ctx context.Context) error
+	key       string
+	telemetry *telemetry
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lost     chan struct{}
+	lostOnce sync.Once
+	lostErr  error
+	mu       sync.Mutex
+}
+
+// newLease tạo lease cho khóa key có thời hạn duration và bắt đầu vòng lặp gia hạn.
+// Các lần gia hạn thất bại được ghi log và metrics qua telemetry của locker (có thể nil).
+//
+// renewFn phải trả về ErrLockOwnershipLost khi khóa không còn thuộc về owner hiện tại.
+func newLease(key string, duration time.Duration, renewFn func(ctx context.Context) error, telemetry *telemetry) *lease {
+	ctx, cancel := context.WithCancel(context.Background())
+	l := &lease{
+		duration:  duration,
+		renewFn:   renewFn,
+		key:       key,
+		telemetry: telemetry,
+		ctx:       ctx,
+		cancel:    cancel,
+		lost:      make(chan struct{}),
+	}
+
+	go l.startRenewLoop()
+
+	return l
+}
+
+// startRenewLoop tự động gia hạn khóa trước khi hết hạn.
+// Điều này ngăn khóa hết hạn trong khi job vẫn đang chạy.
+//
+// Khóa được đánh dấu là đã mất khi khóa thuộc về owner khác, hoặc ngay khi khóa hết hạn
+// (duration kể từ lần gia hạn thành công cuối cùng) mà các lần gia hạn vẫn thất bại.
+func (l *lease) startRenewLoop() {
+	renewInterval := l.duration / 3 * 2 // Gia hạn sau 2/3 thời gian hết hạn
+	retryInterval := l.duration / 6     // Thử lại nhanh hơn sau khi gia hạn thất bại
+	timer := time.NewTimer(renewInterval)
+	defer timer.Stop()
+
+	lastRenewed := time.Now()
+	var lastErr error
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		expiresAt := lastRenewed.Add(l.duration)
+		if lastErr != nil && !time.Now().Before(expiresAt) {
+			// Khóa đã hết hạn mà chưa gia hạn được
+			l.markLost(fmt.Errorf("%w: %v", ErrLockRenewFailed, lastErr))
+			return
+		}
+
+		// Sử dụng context với timeout để tránh block vô hạn, không chờ quá lúc khóa hết hạn
+		started := time.Now()
+		deadline := started.Add(5 * time.Second)
+		if expiresAt.Before(deadline) {
+			deadline = expiresAt
+		}
+		ctx, cancel := context.WithDeadline(l.ctx, deadline)
+		err := l.renewFn(ctx)
+		cancel()
+		if l.ctx.Err() != nil {
+			// Khóa đã được giải phóng trong lúc đang gia hạn
+			return
+		}
+		if errors.Is(err, ErrLockOwnershipLost) {
+			// Khóa đã hết hạn và thuộc về instance khác, không gia hạn nữa
+			l.telemetry.metrics().LockRenewFailed(l.key)
+			l.markLost(err)
+			return
+		}
+		if err != nil {
+			l.telemetry.logger().Warn("scheduler: lock renewal failed", slog.String("key", l.key), slog.Any("error", err))
+			l.telemetry.metrics().LockRenewFailed(l.key)
+			lastErr = err
+			remaining := time.Until(expiresAt)
+			if remaining <= 0 {
+				l.markLost(fmt.Errorf("%w: %v", ErrLockRenewFailed, err))
+				return
+			}
+			// Thử lại khi khóa chưa hết hạn, chậm nhất là đúng lúc khóa hết hạn
+			timer.Reset(min(retryInterval, remaining))
+			continue
+		}
+		lastRenewed = started
+		lastErr = nil
+		timer.Reset(renewInterval)
+	}
+}
+
+// stop dừng vòng lặp gia hạn, được gọi khi khóa được giải phóng.
+func (l *lease) stop() {
+	l.cancel()
+}
+
+// markLost đánh dấu khóa đã bị mất và đóng channel Lost.
+func (l *lease) markLost(err error) {
+	l.lostOnce.Do(func() {
+		l.mu.Lock()
+		l.lostErr = err
+		l.mu.Unlock()
+		close(l.lost)
+	})
+}
+
+// Lost triển khai LockLostNotifier, channel được đóng khi khóa bị mất.
+func (l *lease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Err triển khai LockLostNotifier, trả về nguyên nhân mất khóa.
+func (l *lease) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.lostErr
+}
@@ -2,7 +2,12 @@ package scheduler
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"os"
+	"reflect"
 	"time"
 
 	"github.com/go-co-op/gocron"
@@ -11,23 +16,62 @@ import (
 
 // RedisLockerOptions đã được di chuyển vào config.go
 
+var (
+	// unlockScript chỉ xóa khóa khi giá trị hiện tại khớp với owner token.
+	unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+	// renewScript chỉ gia hạn khóa khi giá trị hiện tại khớp với owner token.
+	renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+)
+
+// LockLostNotifier được implement bởi các gocron.Lock có khả năng báo hiệu khi khóa bị mất
+// trong lúc job đang chạy, ví dụ khi gia hạn thất bại hoặc khóa đã thuộc về instance khác.
+//
+// Manager sử dụng interface này để hủy context của job đang chạy và phát EventLockLost.
+type LockLostNotifier interface {
+	// Lost trả về channel được đóng khi khóa không còn thuộc về instance hiện tại.
+	Lost() <-chan struct{}
+
+	// Err trả về nguyên nhân mất khóa, nil nếu khóa chưa bị mất.
+	Err() error
+}
+
 // redisLocker triển khai gocron.Locker interface sử dụng Redis làm backend.
 type redisLocker struct {
-	client  *redis.Client
-	options RedisLockerOptionsTime
+	client     redis.UniversalClient
+	options    RedisLockerOptionsTime
+	instanceID string
+	telemetry  *telemetry
 }
 
 // redisLock triển khai gocron.Lock interface.
+//
+// Mỗi lần lấy khóa thành công, redisLock giữ một owner token duy nhất. Unlock và
+// gia hạn khóa chỉ có tác dụng khi giá trị trong Redis vẫn khớp với token này.
 type redisLock struct {
-	locker       *redisLocker
-	key          string
-	cancelRenew  context.CancelFunc
-	renewContext context.Context
+	*lease
+
+	locker *redisLocker
+	key    string
+	token  string
 }
 
 // NewRedisLocker tạo một Redis Locker mới để sử dụng với gocron.
 // Nó có thể được chuyển vào phương thức WithDistributedLocker của scheduler.
 //
+// client có thể là bất kỳ redis.UniversalClient nào: *redis.Client, *redis.ClusterClient,
+// failover client của Sentinel hoặc *redis.Ring.
+//
 // Example:
 //
 //	redisClient := redis.NewClient(&redis.Options{
@@ -38,8 +82,8 @@ type redisLock struct {
 //		log.Fatal(err)
 //	}
 //	sched.WithDistributedLocker(locker)
-func NewRedisLocker(client *redis.Client, opts ...RedisLockerOptions) (gocron.Locker, error) {
-	if client == nil {
+func NewRedisLocker(client redis.UniversalClient, opts ...RedisLockerOptions) (gocron.Locker, error) {
+	if isNilClient(client) {
 		return nil, ErrRedisClientNil
 	}
 
@@ -69,21 +113,51 @@ func NewRedisLocker(client *redis.Client, opts ...RedisLockerOptions) (gocron.Lo
 
 	// Tạo locker
 	locker := &redisLocker{
-		client:  client,
-		options: timeOptions,
+		client:     client,
+		options:    timeOptions,
+		instanceID: resolveInstanceID(timeOptions.InstanceID),
 	}
 
 	return locker, nil
 }
 
+// isNilClient kiểm tra client nil, kể cả con trỏ nil được gói trong interface.
+func isNilClient(client redis.UniversalClient) bool {
+	if client == nil {
+		return true
+	}
+	v := reflect.ValueOf(client)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
+
+// lockKey trả về key đầy đủ trong Redis cho job.
+//
+// Khi HashTag được bật, tên job được bọc trong {} để Redis Cluster tính slot theo tên job.
+func (r *redisLocker) lockKey(key string) string {
+	if r.options.HashTag {
+		return r.options.KeyPrefix + "{" + key + "}"
+	}
+	return r.options.KeyPrefix + key
+}
+
+// setTelemetry triển khai instrumented.
+func (r *redisLocker) setTelemetry(telemetry *telemetry) {
+	r.telemetry = telemetry
+}
+
 // Lock triển khai phương thức Lock của gocron.Locker interface.
 func (r *redisLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
-	fullKey := r.options.KeyPrefix + key
+	fullKey := r.lockKey(key)
 	retries := 0
 
+	token, err := newLockToken(r.instanceID)
+	if err != nil {
+		return nil, err
+	}
+
 	for {
-		// Cố gắng set key với expiration
-		success, err := r.client.SetNX(ctx, fullKey, "locked", r.options.LockDuration).Result()
+		// Cố gắng set key với owner token và expiration
+		success, err := r.client.SetNX(ctx, fullKey, token, r.options.LockDuration).Result()
 
 		// Nếu có lỗi không liên quan đến kết nối
 		if err != nil && err != redis.ErrClosed && err != context.Canceled {
@@ -92,16 +166,14 @@ func (r *redisLocker) Lock(ctx context.Context, key string) (gocron.Lock, error)
 
 		// Nếu lock thành công
 		if success {
-			renewCtx, cancelFn := context.WithCancel(context.Background())
 			lock := &redisLock{
-				locker:       r,
-				key:          key,
-				renewContext: renewCtx,
-				cancelRenew:  cancelFn,
+				locker: r,
+				key:    key,
+				token:  token,
 			}
 
 			// Bắt đầu quá trình tự động gia hạn khóa
-			go lock.startRenewLoop()
+			lock.lease = newLease(key, r.options.LockDuration, lock.renew, r.telemetry)
 
 			return lock, nil
 		}
@@ -122,43 +194,64 @@ func (r *redisLocker) Lock(ctx context.Context, key string) (gocron.Lock, error)
 	}
 }
 
-// startRenewLoop bắt đầu một goroutine để tự động gia hạn khóa trước khi hết hạn.
-// Điều này ngăn khóa hết hạn trong khi job vẫn đang chạy.
-func (r *redisLock) startRenewLoop() {
-	renewInterval := r.locker.options.LockDuration / 3 * 2 // Gia hạn sau 2/3 thời gian hết hạn
-	ticker := time.NewTicker(renewInterval)
-	defer ticker.Stop()
-
-	fullKey := r.locker.options.KeyPrefix + r.key
+// renew gia hạn khóa nếu khóa vẫn thuộc về owner token hiện tại.
+// Trả về ErrLockOwnershipLost nếu khóa đã hết hạn hoặc thuộc về instance khác.
+func (r *redisLock) renew(ctx context.Context) error {
+	fullKey := r.locker.lockKey(r.key)
+	ttl := r.locker.options.LockDuration.Milliseconds()
 
-	for {
-		select {
-		case <-r.renewContext.Done():
-			return
-		case <-ticker.C:
-			// Gia hạn khóa bằng cách đặt thời gian hết hạn mới
-			// Sử dụng context với timeout để tránh block vô hạn
-			ctx, cancel := context.WithTimeout(r.renewContext, 5*time.Second)
-			err := r.locker.client.Expire(ctx, fullKey, r.locker.options.LockDuration).Err()
-			cancel()
-			if err != nil {
-				// Log lỗi nếu cần thiết, nhưng không làm gián đoạn vòng lặp
-				continue
-			}
-		}
+	renewed, err := renewScript.Run(ctx, r.locker.client, []string{fullKey}, r.token, ttl).Int()
+	if err != nil {
+		return err
 	}
+	if renewed == 0 {
+		return ErrLockOwnershipLost
+	}
+	return nil
 }
 
 // Unlock triển khai phương thức Unlock của gocron.Lock interface.
+//
+// Khóa chỉ bị xóa nếu vẫn thuộc về owner token hiện tại. Nếu khóa đã hết hạn
+// hoặc đã bị instance khác lấy, Unlock trả về ErrLockOwnershipLost và không
+// xóa khóa của instance đó.
 func (r *redisLock) Unlock(ctx context.Context) error {
 	// Dừng vòng lặp gia hạn trước
-	if r.cancelRenew != nil {
-		r.cancelRenew()
+	r.stop()
+
+	// Sau đó xóa khóa từ Redis nếu token còn khớp
+	fullKey := r.locker.lockKey(r.key)
+	deleted, err := unlockScript.Run(ctx, r.locker.client, []string{fullKey}, r.token).Int()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrLockOwnershipLost
 	}
+	return nil
+}
+
+// resolveInstanceID trả về instanceID nếu đã được cấu hình,
+// ngược lại sinh định danh từ hostname và process id.
+func resolveInstanceID(instanceID string) string {
+	if instanceID != "" {
+		return instanceID
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
 
-	// Sau đó xóa khóa từ Redis
-	fullKey := r.locker.options.KeyPrefix + r.key
-	return r.locker.client.Del(ctx, fullKey).Err()
+// newLockToken sinh owner token duy nhất cho một lần lấy khóa,
+// gồm instance id và một chuỗi ngẫu nhiên.
+func newLockToken(instanceID string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return instanceID + ":" + hex.EncodeToString(buf), nil
 }
 
 // validateRedisLockerOptions kiểm tra tính hợp lệ của các tùy chọn Redis Locker.
@@ -180,6 +273,9 @@ func validateRedisLockerOptions(options RedisLockerOptions) error {
 
 // Error constants
 var (
+	// ErrUniversalClientNotSupported được trả về khi redis provider không cung cấp UniversalClient.
+	ErrUniversalClientNotSupported = errors.New("scheduler: redis manager does not provide a universal client")
+
 	// ErrRedisClientNil được trả về khi Redis client nil.
 	ErrRedisClientNil = errors.New("scheduler: redis client is nil")
 
@@ -200,4 +296,10 @@ var (
 
 	// ErrInvalidKeyPrefix được trả về khi KeyPrefix không hợp lệ.
 	ErrInvalidKeyPrefix = errors.New("scheduler: invalid key prefix")
+
+	// ErrLockOwnershipLost được trả về khi khóa đã hết hạn hoặc đang thuộc về owner khác.
+	ErrLockOwnershipLost = errors.New("scheduler: lock ownership lost")
+
+	// ErrLockRenewFailed được trả về khi không thể gia hạn khóa trước khi khóa hết hạn.
+	ErrLockRenewFailed = errors.New("scheduler: failed to renew lock before expiration")
 )
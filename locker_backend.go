@@ -0,0 +1,227 @@
+package scheduler
+
+import (
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/go-co-op/gocron"
+	goredis "github.com/redis/go-redis/v9"
+	"go.fork.vn/di"
+	"go.fork.vn/redis"
+)
+
+// LockerFactory tạo gocron.Locker cho scheduler từ DI container và cấu hình scheduler.
+type LockerFactory func(container di.Container, cfg Config) (gocron.Locker, error)
+
+// ElectorFactory tạo LeaderElector cho scheduler từ DI container và cấu hình scheduler.
+type ElectorFactory func(container di.Container, cfg Config) (LeaderElector, error)
+
+// PauseStoreFactory tạo PauseStore cho scheduler từ DI container và cấu hình scheduler.
+type PauseStoreFactory func(container di.Container, cfg Config) (PauseStore, error)
+
+// LockerBackend mô tả một backend cho distributed locking được ServiceProvider sử dụng.
+type LockerBackend struct {
+	// Requires là các service provider mà backend phụ thuộc (ví dụ "redis")
+	Requires []string
+
+	// Factory tạo locker khi distributed locking được bật
+	Factory LockerFactory
+
+	// Elector tạo leader elector cho chế độ leader_election, nil nếu backend không hỗ trợ
+	Elector ElectorFactory
+
+	// PauseStore tạo PauseStore khi distributed_lock.share_pause_state được bật, nil nếu backend
+	// không hỗ trợ
+	PauseStore PauseStoreFactory
+}
+
+// lockerBackends là registry các locker backend theo tên.
+var lockerBackends = struct {
+	sync.RWMutex
+	backends map[string]LockerBackend
+}{
+	backends: map[string]LockerBackend{
+		LockDriverRedis: {
+			Requires:   []string{"redis"},
+			Factory:    newRedisLockerFromContainer,
+			Elector:    newRedisElectorFromContainer,
+			PauseStore: newRedisPauseStoreFromContainer,
+		},
+		LockDriverPostgres: {Factory: sqlLockerFactory(LockDriverPostgres), PauseStore: sqlPauseStoreFactory(LockDriverPostgres)},
+		LockDriverMySQL:    {Factory: sqlLockerFactory(LockDriverMySQL), PauseStore: sqlPauseStoreFactory(LockDriverMySQL)},
+		LockDriverSQLite:   {Factory: sqlLockerFactory(LockDriverSQLite), PauseStore: sqlPauseStoreFactory(LockDriverSQLite)},
+		LockBackendMemory:  {Factory: newMemoryLockerFromConfig, Elector: newMemoryElectorFromConfig, PauseStore: newMemoryPauseStoreFromConfig},
+	},
+}
+
+// RegisterLockerBackend đăng ký một locker backend với tên name để chọn qua
+// distributed_lock.backend trong cấu hình.
+//
+// Các backend có sẵn: "redis", "postgres", "mysql", "sqlite" và "memory".
+// RegisterLockerBackend nên được gọi trước khi đăng ký ServiceProvider, ví dụ trong init().
+//
+// Panics nếu name trống, Factory nil hoặc name đã được đăng ký.
+//
+// Example:
+//
+//	scheduler.RegisterLockerBackend("etcd", scheduler.LockerBackend{
+//		Requires: []string{"etcd"},
+//		Factory: func(c di.Container, cfg scheduler.Config) (gocron.Locker, error) {
+//			client, err := c.Make("etcd")
+//			if err != nil {
+//				return nil, err
+//			}
+//			return newEtcdLocker(client.(*clientv3.Client), cfg.Options)
+//		},
+//	})
+func RegisterLockerBackend(name string, backend LockerBackend) {
+	if name == "" {
+		panic("scheduler: locker backend name is empty")
+	}
+	if backend.Factory == nil {
+		panic("scheduler: locker backend " + name + " has nil factory")
+	}
+
+	lockerBackends.Lock()
+	defer lockerBackends.Unlock()
+
+	if _, exists := lockerBackends.backends[name]; exists {
+		panic("scheduler: locker backend " + name + " is already registered")
+	}
+	lockerBackends.backends[name] = backend
+}
+
+// lookupLockerBackend tìm locker backend theo tên.
+func lookupLockerBackend(name string) (LockerBackend, bool) {
+	lockerBackends.RLock()
+	defer lockerBackends.RUnlock()
+
+	backend, ok := lockerBackends.backends[name]
+	return backend, ok
+}
+
+// newRedisLockerFromContainer tạo Redis Locker với client lấy từ redis provider.
+func newRedisLockerFromContainer(container di.Container, cfg Config) (gocron.Locker, error) {
+	redisClient, err := redisClientFromContainer(container, cfg.DistributedLock.RedisClient)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedisLocker(redisClient, cfg.Options)
+}
+
+// newRedisElectorFromContainer tạo Redis Elector với client lấy từ redis provider.
+func newRedisElectorFromContainer(container di.Container, cfg Config) (LeaderElector, error) {
+	redisClient, err := redisClientFromContainer(container, cfg.DistributedLock.RedisClient)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedisElector(redisClient, cfg.Options)
+}
+
+// newRedisPauseStoreFromContainer tạo Redis PauseStore với client lấy từ redis provider.
+func newRedisPauseStoreFromContainer(container di.Container, cfg Config) (PauseStore, error) {
+	redisClient, err := redisClientFromContainer(container, cfg.DistributedLock.RedisClient)
+	if err != nil {
+		return nil, err
+	}
+	return NewRedisPauseStore(redisClient, "")
+}
+
+// redisClientFromContainer lấy Redis client từ redis provider theo client
+// ("default" hoặc "universal", xem DistributedLockConfig.RedisClient).
+func redisClientFromContainer(container di.Container, client string) (goredis.UniversalClient, error) {
+	redisInstance, err := container.Make("redis")
+	if err != nil {
+		return nil, fmt.Errorf("redis service not found: %w", err)
+	}
+
+	redisManager, ok := redisInstance.(redis.Manager)
+	if !ok {
+		return nil, fmt.Errorf("redis service is not a valid redis.Manager interface")
+	}
+
+	redisClient, err := redisLockClient(redisManager, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get redis client: %w", err)
+	}
+
+	return redisClient, nil
+}
+
+// sqlLockerFactory trả về LockerFactory tạo SQL Locker cho driver, với *sql.DB lấy từ
+// DI container theo DistributedLockConfig.Database.
+func sqlLockerFactory(driver string) LockerFactory {
+	return func(container di.Container, cfg Config) (gocron.Locker, error) {
+		db, err := sqlDBFromContainer(container, cfg.DistributedLock.Database)
+		if err != nil {
+			return nil, err
+		}
+		return NewSQLLocker(db, driver, cfg.Options)
+	}
+}
+
+// sqlPauseStoreFactory trả về PauseStoreFactory tạo SQL PauseStore cho driver, với *sql.DB lấy từ
+// DI container theo DistributedLockConfig.Database.
+func sqlPauseStoreFactory(driver string) PauseStoreFactory {
+	return func(container di.Container, cfg Config) (PauseStore, error) {
+		db, err := sqlDBFromContainer(container, cfg.DistributedLock.Database)
+		if err != nil {
+			return nil, err
+		}
+		return NewSQLPauseStore(db, driver)
+	}
+}
+
+// sqlDBFromContainer lấy *sql.DB với key trong DI container, key trống tương đương "db".
+func sqlDBFromContainer(container di.Container, key string) (*sql.DB, error) {
+	if key == "" {
+		key = "db"
+	}
+
+	dbInstance, err := container.Make(key)
+	if err != nil {
+		return nil, fmt.Errorf("database service %s not found: %w", key, err)
+	}
+
+	db, ok := dbInstance.(*sql.DB)
+	if !ok {
+		return nil, fmt.Errorf("database service %s is not a valid *sql.DB", key)
+	}
+
+	return db, nil
+}
+
+// newMemoryLockerFromConfig tạo Memory Locker với store riêng, chỉ phù hợp khi chạy một process.
+func newMemoryLockerFromConfig(container di.Container, cfg Config) (gocron.Locker, error) {
+	return NewMemoryLocker(nil, cfg.Options)
+}
+
+// newMemoryElectorFromConfig tạo Memory Elector với store riêng, chỉ phù hợp khi chạy một process.
+func newMemoryElectorFromConfig(container di.Container, cfg Config) (LeaderElector, error) {
+	return NewMemoryElector(nil, cfg.Options)
+}
+
+// newMemoryPauseStoreFromConfig tạo Memory PauseStore riêng, chỉ phù hợp khi chạy một process.
+func newMemoryPauseStoreFromConfig(container di.Container, cfg Config) (PauseStore, error) {
+	return NewMemoryPauseStore(), nil
+}
+
+// universalClientProvider được implement bởi các redis.Manager hỗ trợ UniversalClient
+// (Cluster, Sentinel, Ring).
+type universalClientProvider interface {
+	UniversalClient() (goredis.UniversalClient, error)
+}
+
+// redisLockClient lấy Redis client cho distributed locking từ redis provider theo
+// DistributedLockConfig.RedisClient.
+func redisLockClient(redisManager redis.Manager, client string) (goredis.UniversalClient, error) {
+	if client == RedisClientUniversal {
+		provider, ok := redisManager.(universalClientProvider)
+		if !ok {
+			return nil, ErrUniversalClientNotSupported
+		}
+		return provider.UniversalClient()
+	}
+	return redisManager.Client()
+}
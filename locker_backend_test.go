@@ -0,0 +1,177 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/go-co-op/gocron"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	configMocks "go.fork.vn/config/mocks"
+	"go.fork.vn/di"
+	diMocks "go.fork.vn/di/mocks"
+)
+
+func TestBuiltInLockerBackends(t *testing.T) {
+	for _, name := range []string{LockDriverRedis, LockDriverPostgres, LockDriverMySQL, LockDriverSQLite, LockBackendMemory} {
+		backend, ok := lookupLockerBackend(name)
+		assert.True(t, ok, "backend %s should be registered", name)
+		assert.NotNil(t, backend.Factory)
+	}
+
+	redisBackend, _ := lookupLockerBackend(LockDriverRedis)
+	assert.Equal(t, []string{"redis"}, redisBackend.Requires)
+
+	sqliteBackend, _ := lookupLockerBackend(LockDriverSQLite)
+	assert.Empty(t, sqliteBackend.Requires)
+}
+
+func TestRegisterLockerBackendPanics(t *testing.T) {
+	factory := func(container di.Container, cfg Config) (gocron.Locker, error) { return nil, nil }
+
+	assert.Panics(t, func() { RegisterLockerBackend("", LockerBackend{Factory: factory}) })
+	assert.Panics(t, func() { RegisterLockerBackend("no-factory", LockerBackend{}) })
+	assert.Panics(t, func() { RegisterLockerBackend(LockDriverRedis, LockerBackend{Factory: factory}) })
+}
+
+func TestDistributedLockConfigBackendName(t *testing.T) {
+	assert.Equal(t, LockDriverRedis, DistributedLockConfig{}.BackendName())
+	assert.Equal(t, LockDriverSQLite, DistributedLockConfig{Driver: LockDriverSQLite}.BackendName())
+	assert.Equal(t, "custom", DistributedLockConfig{Backend: "custom", Driver: LockDriverSQLite}.BackendName())
+}
+
+func TestServiceProviderRegisterWithCustomLockerBackend(t *testing.T) {
+	var factoryCalls int
+	RegisterLockerBackend("test-custom", LockerBackend{
+		Requires: []string{"etcd"},
+		Factory: func(container di.Container, cfg Config) (gocron.Locker, error) {
+			factoryCalls++
+			return NewMemoryLocker(nil, cfg.Options)
+		},
+	})
+
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.Backend = "test-custom"
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager"))
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	assert.Equal(t, 1, factoryCalls)
+	assert.Equal(t, []string{"config", "etcd"}, provider.Requires())
+}
+
+func TestServiceProviderRegisterWithUnknownLockerBackend(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.Backend = "missing"
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+
+	provider := NewServiceProvider()
+	assert.PanicsWithValue(t, "scheduler: invalid scheduler configuration: scheduler: unknown locker backend", func() {
+		provider.Register(mockApp)
+	})
+}
+
+func TestServiceProviderRegisterWithLeaderElection(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.Backend = LockBackendMemory
+	cfg.DistributedLock.Mode = LockModeLeaderElection
+
+	var registered *manager
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager")).Run(func(key string, instance interface{}) {
+		registered = instance.(*manager)
+	})
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	assert.NotNil(t, registered.elector)
+}
+
+func TestServiceProviderRegisterWithSharedPauseState(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	db := newTestSQLiteDB(t)
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.Driver = LockDriverSQLite
+	cfg.DistributedLock.Database = "database.default"
+	cfg.DistributedLock.SharePauseState = true
+
+	var registered *manager
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Make("database.default").Return(db, nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager")).Run(func(key string, instance interface{}) {
+		registered = instance.(*manager)
+	})
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	_, ok := registered.pauses.(*sqlPauseStore)
+	assert.True(t, ok, "manager should use the SQL pause store")
+}
+
+func TestSharePauseStateRequiresBackendSupport(t *testing.T) {
+	RegisterLockerBackend("test-no-pause", LockerBackend{
+		Factory: func(container di.Container, cfg Config) (gocron.Locker, error) {
+			return NewMemoryLocker(nil, cfg.Options)
+		},
+	})
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.Backend = "test-no-pause"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.DistributedLock.SharePauseState = true
+	assert.ErrorIs(t, cfg.Validate(), ErrPauseStateNotSupported)
+
+	cfg.DistributedLock.Backend = LockBackendMemory
+	assert.NoError(t, cfg.Validate())
+}
@@ -2,12 +2,33 @@ package scheduler
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
 	"github.com/go-co-op/gocron"
 	"github.com/redis/go-redis/v9"
 )
 
+// newTestRedisLocker tạo Redis Locker kết nối tới một miniredis server cho test.
+func newTestRedisLocker(t *testing.T, opts ...RedisLockerOptions) (*miniredis.Miniredis, *redisLocker) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	locker, err := NewRedisLocker(client, opts...)
+	if err != nil {
+		t.Fatalf("Failed to create redis locker: %v", err)
+	}
+
+	return server, locker.(*redisLocker)
+}
+
 func TestDefaultRedisLockerOptions(t *testing.T) {
 	options := DefaultRedisLockerOptions()
 
@@ -138,6 +159,70 @@ func TestNewRedisLockerWithNilClient(t *testing.T) {
 	}
 }
 
+func TestNewRedisLockerWithTypedNilClient(t *testing.T) {
+	var client *redis.Client
+	_, err := NewRedisLocker(client)
+	if err != ErrRedisClientNil {
+		t.Errorf("Expected ErrRedisClientNil, got %v", err)
+	}
+}
+
+func TestNewRedisLockerWithUniversalClient(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	clients := map[string]redis.UniversalClient{
+		"universal": redis.NewUniversalClient(&redis.UniversalOptions{Addrs: []string{server.Addr()}}),
+		"ring":      redis.NewRing(&redis.RingOptions{Addrs: map[string]string{"shard1": server.Addr()}}),
+	}
+
+	for name, client := range clients {
+		t.Run(name, func(t *testing.T) {
+			defer client.Close()
+
+			locker, err := NewRedisLocker(client)
+			if err != nil {
+				t.Fatalf("Failed to create redis locker: %v", err)
+			}
+
+			lock, err := locker.Lock(context.Background(), name)
+			if err != nil {
+				t.Fatalf("Failed to acquire lock: %v", err)
+			}
+			if !server.Exists("scheduler_lock:" + name) {
+				t.Error("Lock key should exist after Lock")
+			}
+			if err := lock.Unlock(context.Background()); err != nil {
+				t.Errorf("Failed to unlock: %v", err)
+			}
+		})
+	}
+}
+
+func TestRedisLockerHashTag(t *testing.T) {
+	server, locker := newTestRedisLocker(t, RedisLockerOptions{
+		KeyPrefix:    "scheduler_lock:",
+		LockDuration: 30,
+		MaxRetries:   1,
+		RetryDelay:   10,
+		HashTag:      true,
+	})
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	if !server.Exists("scheduler_lock:{job}") {
+		t.Error("Expected lock key wrapped in hash tag")
+	}
+
+	if err := lock.Unlock(context.Background()); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+	if server.Exists("scheduler_lock:{job}") {
+		t.Error("Lock key should be deleted after Unlock")
+	}
+}
+
 func TestNewRedisLockerWithInvalidOptions(t *testing.T) {
 	// Skip test nếu không có Redis (vì chúng ta không thể tạo client thật)
 	// Tạo mock client
@@ -202,3 +287,274 @@ func TestRedisLockerInterface(t *testing.T) {
 		t.Error("Lock was not unlocked")
 	}
 }
+
+func TestRedisLockerLockStoresOwnerToken(t *testing.T) {
+	options := DefaultRedisLockerOptions()
+	options.InstanceID = "node-1"
+	server, locker := newTestRedisLocker(t, options)
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer lock.Unlock(context.Background())
+
+	value, err := server.Get("scheduler_lock:job")
+	if err != nil {
+		t.Fatalf("Lock key not found: %v", err)
+	}
+
+	if !strings.HasPrefix(value, "node-1:") {
+		t.Errorf("Expected owner token prefixed with instance id, got %q", value)
+	}
+
+	if value != lock.(*redisLock).token {
+		t.Errorf("Expected stored value to equal lock token, got %q", value)
+	}
+}
+
+func TestRedisLockerTokensAreUniquePerAcquisition(t *testing.T) {
+	_, locker := newTestRedisLocker(t)
+
+	first, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	if err := first.Unlock(context.Background()); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+
+	second, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer second.Unlock(context.Background())
+
+	if first.(*redisLock).token == second.(*redisLock).token {
+		t.Error("Expected a new owner token for each acquisition")
+	}
+}
+
+func TestRedisLockerLockHeldByOtherOwner(t *testing.T) {
+	options := DefaultRedisLockerOptions()
+	options.MaxRetries = 0
+	server, locker := newTestRedisLocker(t, options)
+
+	server.Set("scheduler_lock:job", "other-node:token")
+
+	_, err := locker.Lock(context.Background(), "job")
+	if err != ErrFailedToAcquireLock {
+		t.Errorf("Expected ErrFailedToAcquireLock, got %v", err)
+	}
+}
+
+func TestRedisLockUnlockOnlyDeletesOwnLock(t *testing.T) {
+	server, locker := newTestRedisLocker(t)
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	// Giả lập khóa đã hết hạn và bị instance khác lấy
+	server.Set("scheduler_lock:job", "other-node:token")
+
+	err = lock.Unlock(context.Background())
+	if !errors.Is(err, ErrLockOwnershipLost) {
+		t.Errorf("Expected ErrLockOwnershipLost, got %v", err)
+	}
+
+	value, err := server.Get("scheduler_lock:job")
+	if err != nil || value != "other-node:token" {
+		t.Errorf("Lock of other owner must not be deleted, got %q (%v)", value, err)
+	}
+}
+
+func TestRedisLockUnlockDeletesOwnLock(t *testing.T) {
+	server, locker := newTestRedisLocker(t)
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	if err := lock.Unlock(context.Background()); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+
+	if server.Exists("scheduler_lock:job") {
+		t.Error("Lock key should be deleted after Unlock")
+	}
+}
+
+func TestRedisLockRenewOnlyExtendsOwnLock(t *testing.T) {
+	server, locker := newTestRedisLocker(t)
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer lock.Unlock(context.Background())
+
+	rl := lock.(*redisLock)
+
+	// Gia hạn khóa của chính mình
+	server.SetTTL("scheduler_lock:job", time.Second)
+	if err := rl.renew(context.Background()); err != nil {
+		t.Fatalf("Failed to renew own lock: %v", err)
+	}
+	if ttl := server.TTL("scheduler_lock:job"); ttl != 30*time.Second {
+		t.Errorf("Expected TTL to be extended to 30s, got %v", ttl)
+	}
+
+	// Khóa đã thuộc về instance khác
+	server.Set("scheduler_lock:job", "other-node:token")
+	server.SetTTL("scheduler_lock:job", time.Second)
+
+	err = rl.renew(context.Background())
+	if !errors.Is(err, ErrLockOwnershipLost) {
+		t.Errorf("Expected ErrLockOwnershipLost, got %v", err)
+	}
+	if ttl := server.TTL("scheduler_lock:job"); ttl != time.Second {
+		t.Errorf("TTL of other owner's lock must not change, got %v", ttl)
+	}
+}
+
+func TestRedisLockLostWhenOwnershipChanges(t *testing.T) {
+	server, locker := newTestRedisLocker(t, RedisLockerOptions{
+		KeyPrefix:    "scheduler_lock:",
+		LockDuration: 1,
+		MaxRetries:   1,
+		RetryDelay:   10,
+	})
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer lock.Unlock(context.Background())
+
+	notifier, ok := lock.(LockLostNotifier)
+	if !ok {
+		t.Fatal("redisLock should implement LockLostNotifier")
+	}
+	if notifier.Err() != nil {
+		t.Fatalf("Expected no error before the lock is lost, got %v", notifier.Err())
+	}
+
+	// Khóa hết hạn và bị instance khác lấy
+	server.Set("scheduler_lock:job", "other-node:token")
+
+	select {
+	case <-notifier.Lost():
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected lost signal after ownership changed")
+	}
+	if !errors.Is(notifier.Err(), ErrLockOwnershipLost) {
+		t.Errorf("Expected ErrLockOwnershipLost, got %v", notifier.Err())
+	}
+}
+
+func TestRedisLockLostWhenRenewalKeepsFailing(t *testing.T) {
+	server, locker := newTestRedisLocker(t, RedisLockerOptions{
+		KeyPrefix:    "scheduler_lock:",
+		LockDuration: 1,
+		MaxRetries:   1,
+		RetryDelay:   10,
+	})
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	notifier := lock.(LockLostNotifier)
+
+	// Redis không còn truy cập được, gia hạn thất bại cho đến khi khóa hết hạn
+	server.Close()
+
+	select {
+	case <-notifier.Lost():
+	case <-time.After(4 * time.Second):
+		t.Fatal("Expected lost signal after renewal failures past expiration")
+	}
+	if !errors.Is(notifier.Err(), ErrLockRenewFailed) {
+		t.Errorf("Expected ErrLockRenewFailed, got %v", notifier.Err())
+	}
+}
+
+func TestLeaseLostAtExpiration(t *testing.T) {
+	duration := 300 * time.Millisecond
+	acquired := time.Now()
+	l := newLease("job", duration, func(ctx context.Context) error {
+		return errors.New("redis down")
+	}, nil)
+	defer l.stop()
+
+	select {
+	case <-l.Lost():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected lease to be lost")
+	}
+
+	// Khóa được báo mất ngay khi hết hạn, không chờ thêm một chu kỳ gia hạn
+	elapsed := time.Since(acquired)
+	if elapsed < duration || elapsed >= duration*4/3 {
+		t.Errorf("Expected lease to be lost at expiration (%v), got %v", duration, elapsed)
+	}
+	if !errors.Is(l.Err(), ErrLockRenewFailed) {
+		t.Errorf("Expected ErrLockRenewFailed, got %v", l.Err())
+	}
+}
+
+func TestLeaseRetriesRenewalBeforeExpiration(t *testing.T) {
+	var calls atomic.Int32
+	l := newLease("job", 300*time.Millisecond, func(ctx context.Context) error {
+		if calls.Add(1) == 1 {
+			return errors.New("redis down")
+		}
+		return nil
+	}, nil)
+	defer l.stop()
+
+	select {
+	case <-l.Lost():
+		t.Fatalf("Expected lease to survive a transient renewal failure, got %v", l.Err())
+	case <-time.After(time.Second):
+	}
+	if calls.Load() < 2 {
+		t.Errorf("Expected renewal to be retried, got %d calls", calls.Load())
+	}
+}
+
+func TestRedisLockNotLostAfterUnlock(t *testing.T) {
+	_, locker := newTestRedisLocker(t, RedisLockerOptions{
+		KeyPrefix:    "scheduler_lock:",
+		LockDuration: 1,
+		MaxRetries:   1,
+		RetryDelay:   10,
+	})
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	if err := lock.Unlock(context.Background()); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+
+	select {
+	case <-lock.(LockLostNotifier).Lost():
+		t.Fatal("Lost must not be signalled after a normal Unlock")
+	case <-time.After(1500 * time.Millisecond):
+	}
+}
+
+func TestResolveInstanceID(t *testing.T) {
+	if got := resolveInstanceID("node-1"); got != "node-1" {
+		t.Errorf("Expected configured instance id, got %q", got)
+	}
+
+	if got := resolveInstanceID(""); got == "" {
+		t.Error("Expected generated instance id")
+	}
+}
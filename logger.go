@@ -0,0 +1,98 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+)
+
+// withLevel trả về logger chỉ ghi các bản ghi từ level trở lên.
+func withLevel(logger *slog.Logger, level slog.Leveler) *slog.Logger {
+	if logger == nil {
+		return nil
+	}
+	return slog.New(&levelHandler{Handler: logger.Handler(), level: level})
+}
+
+// levelHandler lọc các bản ghi dưới level trước khi chuyển cho handler gốc.
+type levelHandler struct {
+	slog.Handler
+	level slog.Leveler
+}
+
+// Enabled triển khai slog.Handler.
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level() && h.Handler.Enabled(ctx, level)
+}
+
+// WithAttrs triển khai slog.Handler.
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+// WithGroup triển khai slog.Handler.
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// discardLogger là logger không ghi log.
+var discardLogger = slog.New(discardHandler{})
+
+// discardHandler là slog.Handler bỏ qua mọi bản ghi.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// logEvent ghi log cho các sự kiện vòng đời của job và scheduler.
+func (m *manager) logEvent(event Event) {
+	logger := m.telemetry.logger()
+	attrs := []any{}
+	if event.JobName != "" {
+		attrs = append(attrs, slog.String("job", event.JobName))
+	}
+	if len(event.Tags) > 0 {
+		attrs = append(attrs, slog.Any("tags", event.Tags))
+	}
+	if event.Attempt > 0 {
+		attrs = append(attrs, slog.Int("attempt", event.Attempt))
+	}
+
+	switch event.Type {
+	case EventJobStarted:
+		logger.Debug("scheduler: job started", attrs...)
+	case EventJobSucceeded:
+		logger.Info("scheduler: job finished", append(attrs, slog.Duration("duration", event.Duration))...)
+	case EventJobFailed:
+		logger.Error("scheduler: job failed", append(attrs, slog.Duration("duration", event.Duration), slog.Any("error", event.Err))...)
+	case EventJobPanicked:
+		attrs = append(attrs, slog.Any("error", event.Err))
+		if panicErr, ok := event.Err.(*PanicError); ok {
+			attrs = append(attrs, slog.String("stack", string(panicErr.Stack)))
+		}
+		logger.Error("scheduler: job panicked", attrs...)
+	case EventJobRetrying:
+		logger.Warn("scheduler: job will be retried", append(attrs, slog.Any("error", event.Err))...)
+	case EventJobTimeout:
+		logger.Error("scheduler: job timed out", append(attrs, slog.Duration("timeout", event.Duration))...)
+	case EventLockLost:
+		logger.Error("scheduler: job lost its distributed lock", append(attrs, slog.Any("error", event.Err))...)
+	case EventLeadershipAcquired:
+		logger.Info("scheduler: leadership acquired")
+	case EventLeadershipLost:
+		logger.Warn("scheduler: leadership lost")
+	case EventJobsSynced:
+		if event.Err != nil {
+			logger.Error("scheduler: failed to sync configured jobs", slog.Any("error", event.Err))
+		} else {
+			logger.Info("scheduler: configured jobs synced")
+		}
+	case EventJobPaused:
+		logger.Info("scheduler: job paused", attrs...)
+	case EventJobResumed:
+		logger.Info("scheduler: job resumed", attrs...)
+	case EventJobRescheduled:
+		logger.Info("scheduler: job rescheduled", attrs...)
+	}
+}
@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer là bytes.Buffer an toàn khi ghi đồng thời.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// newTestLogger tạo logger ghi mọi level vào buffer.
+func newTestLogger() (*slog.Logger, *syncBuffer) {
+	buf := &syncBuffer{}
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})), buf
+}
+
+func TestWithLevel(t *testing.T) {
+	logger, buf := newTestLogger()
+	leveled := withLevel(logger, slog.LevelWarn).With("component", "scheduler")
+
+	leveled.Info("hidden")
+	leveled.Warn("shown")
+
+	out := buf.String()
+	if strings.Contains(out, "hidden") {
+		t.Errorf("Expected info record to be filtered, got %q", out)
+	}
+	if !strings.Contains(out, "shown") || !strings.Contains(out, "component=scheduler") {
+		t.Errorf("Expected warn record with attributes, got %q", out)
+	}
+	if withLevel(nil, slog.LevelInfo) != nil {
+		t.Error("Expected nil logger to stay nil")
+	}
+}
+
+func TestManagerLogsJobLifecycle(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LogLevel = "debug"
+	logger, buf := newTestLogger()
+	scheduler := NewSchedulerWithConfig(cfg).WithLogger(logger)
+
+	done := make(chan struct{})
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobFailed {
+			close(done)
+		}
+	})
+
+	_, err := scheduler.Every(1).Hours().Name("report").DoContext(func(ctx context.Context) error {
+		return errors.New("boom")
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to fail")
+	}
+	scheduler.Stop()
+
+	out := buf.String()
+	for _, want := range []string{
+		`msg="scheduler: started"`,
+		`msg="scheduler: job started" job=report attempt=1`,
+		`msg="scheduler: job failed" job=report attempt=1`,
+		"error=boom",
+		`msg="scheduler: stopped"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected log %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestManagerLogLevelFiltersRecords(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LogLevel = "error"
+	logger, buf := newTestLogger()
+	scheduler := NewSchedulerWithConfig(cfg).WithLogger(logger)
+
+	scheduler.StartAsync()
+	scheduler.Stop()
+
+	if out := buf.String(); out != "" {
+		t.Errorf("Expected info records to be filtered, got %q", out)
+	}
+}
+
+func TestManagerLogsLockAcquisition(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LogLevel = "debug"
+	logger, buf := newTestLogger()
+
+	store := NewMemoryLockStore()
+	locker, err := NewMemoryLocker(store)
+	if err != nil {
+		t.Fatalf("Failed to create locker: %v", err)
+	}
+	scheduler := NewSchedulerWithConfig(cfg).WithLogger(logger).WithDistributedLocker(locker)
+
+	// Khóa của job đang được giữ bởi instance khác
+	other, _ := NewMemoryLocker(store)
+	held, err := other.Lock(context.Background(), "held")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer held.Unlock(context.Background())
+
+	done := make(chan struct{})
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobSucceeded {
+			close(done)
+		}
+	})
+	if _, err := scheduler.Every(1).Hours().Name("free").DoContext(func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if _, err := scheduler.Every(1).Hours().Name("held").DoContext(func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected free job to run")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(buf.String(), "lock not acquired") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `msg="scheduler: lock acquired" key=free`) {
+		t.Errorf("Expected lock acquired log, got:\n%s", out)
+	}
+	if !strings.Contains(out, `msg="scheduler: lock not acquired, skipping run" key=held`) {
+		t.Errorf("Expected lock skipped log, got:\n%s", out)
+	}
+}
+
+func TestLeaseLogsRenewFailures(t *testing.T) {
+	logger, buf := newTestLogger()
+
+	l := newLease("renew-test", 30*time.Millisecond, func(ctx context.Context) error {
+		return errors.New("redis down")
+	}, newTelemetry(logger))
+	defer l.stop()
+
+	select {
+	case <-l.Lost():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected lease to be lost")
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `msg="scheduler: lock renewal failed" key=renew-test error="redis down"`) {
+		t.Errorf("Expected renew failure log, got:\n%s", out)
+	}
+}
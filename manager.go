@@ -1,9 +1,16 @@
 package scheduler
 
 import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-co-op/gocron"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Manager là interface chính cho việc quản lý lịch trình công việc, wrapping gocron.
@@ -12,6 +19,34 @@ type Manager interface {
 	// Hữu ích khi chạy scheduler trên nhiều máy chủ trong môi trường phân tán.
 	WithDistributedLocker(locker gocron.Locker) Manager
 
+	// WithLeaderElector thiết lập leader elector cho scheduler, thay thế cho khóa theo từng job.
+	// Chỉ instance đang là leader mới chạy job. Elector được khởi động khi scheduler start
+	// và từ bỏ leadership khi scheduler dừng.
+	WithLeaderElector(elector LeaderElector) Manager
+
+	// WithLogger thiết lập logger cho scheduler, locker và elector đã gắn vào scheduler.
+	// Các bản ghi dưới Config.LogLevel bị bỏ qua; nil nghĩa là không ghi log.
+	// Mặc định scheduler sử dụng slog.Default().
+	WithLogger(logger *slog.Logger) Manager
+
+	// WithMetrics thiết lập MetricsCollector thu thập metrics của job và khóa phân tán,
+	// kể cả của locker đã gắn vào scheduler. nil nghĩa là không thu thập metrics (mặc định).
+	WithMetrics(collector MetricsCollector) Manager
+
+	// WithTracerProvider thiết lập TracerProvider để tạo span OpenTelemetry cho mỗi lần chạy job,
+	// với span con cho việc lấy khóa phân tán. Context của span được truyền cho hàm của job.
+	// nil nghĩa là không tạo span (mặc định).
+	WithTracerProvider(provider trace.TracerProvider) Manager
+
+	// WithHistory thiết lập HistoryStore lưu lịch sử các lần chạy job (job, tag, thời điểm,
+	// instance, kết quả, lỗi, lần thử). nil nghĩa là không lưu lịch sử (mặc định).
+	WithHistory(store HistoryStore) Manager
+
+	// History trả về tối đa limit lần chạy gần nhất của job, mới nhất trước, bỏ qua offset
+	// lần chạy đầu tiên. limit không dương sẽ dùng DefaultHistoryPageSize.
+	// Trả về ErrHistoryNotConfigured nếu chưa thiết lập HistoryStore.
+	History(ctx context.Context, job string, limit, offset int) ([]JobRun, error)
+
 	// Every tạo một công việc mới với khoảng thời gian được chỉ định.
 	// Trả về Manager để hỗ trợ fluent interface.
 	Every(interval interface{}) Manager
@@ -61,14 +96,63 @@ type Manager interface {
 	// Trả về Manager để hỗ trợ fluent interface.
 	Tag(tags ...string) Manager
 
-	// SingletonMode đặt công việc ở chế độ singleton (không chạy đồng thời).
+	// SingletonMode đặt công việc ở chế độ singleton (không chạy đồng thời): lần chạy mới chờ
+	// lần chạy trước kết thúc. Lần chạy vẫn đi qua distributed locker và leader elector.
 	// Trả về Manager để hỗ trợ fluent interface.
 	SingletonMode() Manager
 
+	// Timeout đặt thời gian chạy tối đa của công việc (tính cả các lần thử lại), áp dụng cho
+	// DoContext và DoHandler. Khi hết thời gian, context bị hủy, EventJobTimeout được phát
+	// và khóa phân tán được giải phóng. Hàm công việc không dừng khi context bị hủy vẫn chạy ở
+	// nền và có thể chạy chồng với lần chạy kế tiếp, kể cả ở chế độ singleton hoặc khi dùng
+	// khóa phân tán; lần chạy đó vẫn được báo là đang chạy và Stop, Shutdown chờ nó kết thúc.
+	// 0 nghĩa là dùng Config.DefaultTimeout.
+	// Trả về Manager để hỗ trợ fluent interface.
+	Timeout(d time.Duration) Manager
+
+	// Retry đặt chính sách thử lại khi công việc thất bại, áp dụng cho DoContext và DoHandler.
+	// Các lần thử lại diễn ra trong khi vẫn giữ khóa phân tán của công việc.
+	// Trả về Manager để hỗ trợ fluent interface.
+	Retry(policy RetryPolicy) Manager
+
+	// Middleware thêm các JobMiddleware chỉ áp dụng cho công việc đang được cấu hình,
+	// bên trong các middleware đăng ký qua Use.
+	// Trả về Manager để hỗ trợ fluent interface.
+	Middleware(middleware ...JobMiddleware) Manager
+
 	// Do đặt hàm để thực thi cho công việc với các tham số tùy chọn.
+	// Hàm được thực thi như DoContext: panic được recover, sự kiện được phát và
+	// Retry, Timeout được áp dụng; nếu hàm trả về error, lỗi được coi là lỗi của job.
 	// Trả về Job và error nếu có.
 	Do(jobFun interface{}, params ...interface{}) (*gocron.Job, error)
 
+	// DoContext đặt hàm nhận context để thực thi cho công việc.
+	// Context bị hủy khi scheduler dừng hoặc khi khóa phân tán của job bị mất trong lúc
+	// job đang chạy; JobInfoFromContext trả về thông tin của job. Lỗi trả về được phát
+	// qua EventJobFailed.
+	// Nếu chưa gọi Name, tên job mặc định là tên của jobFun.
+	// Trả về Job và error nếu có.
+	DoContext(jobFun JobFunc) (*gocron.Job, error)
+
+	// DoHandler đặt handler đã đăng ký trong Registry() với tên name để thực thi cho công việc.
+	// Handler được tra cứu tại mỗi lần chạy; nếu chưa được đăng ký, lần chạy trả về
+	// ErrJobHandlerNotFound. Nếu chưa gọi Name, tên job mặc định là tên handler.
+	// Trả về Job và error nếu có.
+	DoHandler(name string) (*gocron.Job, error)
+
+	// ScheduleJob lên lịch job theo JobConfig với handler được tra cứu theo tên trong Registry()
+	// tại mỗi lần chạy. Nếu handler chưa được đăng ký, lần chạy trả về ErrJobHandlerNotFound.
+	// Trả về Job và error nếu có.
+	ScheduleJob(job JobConfig) (*gocron.Job, error)
+
+	// SyncJobs đối chiếu các job được lên lịch từ JobConfig với danh sách jobs: thêm job mới,
+	// xóa job đã bị xóa hoặc tắt, lên lịch lại job có cấu hình thay đổi. Các lần chạy đang
+	// diễn ra không bị gián đoạn. Kết quả được phát qua EventJobsSynced.
+	SyncJobs(jobs []JobConfig) error
+
+	// Registry trả về JobRegistry chứa các handler của job theo tên.
+	Registry() *JobRegistry
+
 	// Name đặt tên cho công việc đang được cấu hình.
 	// Trả về Manager để hỗ trợ fluent interface.
 	Name(name string) Manager
@@ -88,9 +172,15 @@ type Manager interface {
 	// StartBlocking bắt đầu scheduler và chặn luồng hiện tại.
 	StartBlocking()
 
-	// Stop dừng scheduler.
+	// Stop dừng scheduler: context của các job đang chạy bị hủy ngay, sau đó Stop chờ các
+	// hàm job trả về. Dùng Shutdown để chờ các job kết thúc trước khi hủy context của chúng.
 	Stop()
 
+	// Shutdown ngừng lên lịch các lần chạy mới và chờ các job đang chạy kết thúc cho tới
+	// khi ctx hết hạn. Khi hết hạn, context của các job bị hủy, các khóa phân tán đang giữ
+	// được giải phóng và *ShutdownError chứa tên các job còn đang chạy được trả về.
+	Shutdown(ctx context.Context) error
+
 	// IsRunning kiểm tra xem scheduler có đang chạy không.
 	IsRunning() bool
 
@@ -102,75 +192,230 @@ type Manager interface {
 
 	// RegisterEventListeners đăng ký các listener cho các sự kiện.
 	RegisterEventListeners(eventListeners ...gocron.EventListener)
+
+	// OnEvent đăng ký các handler nhận sự kiện vòng đời của job (ví dụ EventLockLost).
+	OnEvent(handlers ...EventHandler)
+
+	// Use đăng ký các JobMiddleware áp dụng cho mọi lần chạy của tất cả các công việc,
+	// kể cả các công việc đã được lên lịch trước đó. Middleware đăng ký trước là lớp ngoài cùng.
+	Use(middleware ...JobMiddleware)
+
+	// Jobs trả về trạng thái của tất cả các công việc (tên, tag, lịch chạy, lần chạy kế tiếp,
+	// lần chạy gần nhất, lỗi gần nhất, đang chạy, tạm dừng), sắp xếp theo tên.
+	Jobs() []JobStatus
+
+	// RunNow chạy ngay các công việc có tên name trong goroutine riêng, ngoài lịch chạy và
+	// kể cả khi công việc đang bị tạm dừng. Lần chạy đi qua cùng pipeline với lần chạy theo lịch
+	// (leader election, khóa phân tán, middleware, retry, timeout, lịch sử, singleton).
+	// RunHandle trả về cho phép chờ và lấy kết quả của các lần chạy; lần chạy bị bỏ qua do không
+	// phải leader hoặc không lấy được khóa có lỗi bọc ErrRunSkipped.
+	// Trả về ErrJobNotFound nếu không có công việc nào được lên lịch qua Manager với tên name.
+	RunNow(name string) (*RunHandle, error)
+
+	// RunByTag chạy ngay các công việc có tag như RunNow.
+	// Trả về ErrJobNotFound nếu không có công việc nào có tag.
+	RunByTag(tag string) (*RunHandle, error)
+
+	// WithPauseStore thiết lập PauseStore lưu trạng thái tạm dừng của công việc và tag.
+	// Các instance dùng chung một PauseStore cùng tạm dừng và tiếp tục công việc.
+	// Mặc định trạng thái được lưu trong bộ nhớ của instance (NewMemoryPauseStore).
+	WithPauseStore(store PauseStore) Manager
+
+	// Pause tạm dừng các công việc có tên name: các lần chạy theo lịch bị bỏ qua (không lấy
+	// khóa phân tán) cho tới khi Resume, lịch chạy được giữ nguyên. Lần chạy đang diễn ra
+	// không bị gián đoạn. Trạng thái được lưu theo tên nên vẫn giữ nguyên khi công việc được
+	// lên lịch lại qua SyncJobs. Kết quả được phát qua EventJobPaused.
+	// Trả về ErrJobNotFound nếu không có công việc nào có tên name.
+	Pause(name string) error
+
+	// Resume tiếp tục các công việc đã tạm dừng qua Pause. Công việc vẫn bị tạm dừng nếu
+	// một tag của nó đang bị tạm dừng qua PauseByTag. Kết quả được phát qua EventJobResumed.
+	Resume(name string) error
+
+	// PauseByTag tạm dừng các công việc có tag như Pause, kể cả các công việc có tag được
+	// lên lịch sau đó.
+	PauseByTag(tag string) error
+
+	// ResumeByTag tiếp tục các công việc đã tạm dừng qua PauseByTag.
+	ResumeByTag(tag string) error
+
+	// IsPaused kiểm tra công việc có tên name có đang bị tạm dừng (theo tên hoặc theo tag) hay không.
+	IsPaused(name string) bool
+
+	// PauseState trả về các công việc và tag đang bị tạm dừng từ PauseStore.
+	PauseState(ctx context.Context) (PauseState, error)
+
+	// Reschedule thay lịch chạy của các công việc có tên name bằng schedule mà không xóa và đăng ký
+	// lại công việc: hàm, tag, middleware, retry, timeout, chế độ singleton, lịch sử và trạng thái
+	// tạm dừng được giữ nguyên. Lần chạy đang diễn ra không bị gián đoạn; ở chế độ singleton, lần
+	// chạy theo lịch mới chờ lần chạy đó kết thúc. Công việc chờ tới lịch chạy kế tiếp theo
+	// schedule. Nếu không lên lịch lại được một công việc, không công việc nào bị thay đổi.
+	// Lịch chạy mới chỉ được giữ trong bộ nhớ: với công việc khai báo trong cấu hình, SyncJobs (ví
+	// dụ khi cấu hình được nạp lại) giữ lịch chạy mới cho tới khi lịch chạy của công việc trong cấu
+	// hình thay đổi. Kết quả được phát qua EventJobRescheduled.
+	// Trả về ErrInvalidJobSchedule, ErrInvalidJobInterval hoặc ErrInvalidSchedule nếu schedule
+	// không hợp lệ và ErrJobNotFound nếu không có công việc nào được lên lịch qua Manager với tên name.
+	Reschedule(name string, schedule JobSchedule) error
+
+	// RemoveByName xóa các công việc có tên name.
+	// Trả về ErrJobNotFound nếu không có công việc nào có tên name.
+	RemoveByName(name string) error
 }
 
 // manager triển khai interface Manager bằng cách nhúng gocron.Scheduler.
 type manager struct {
 	*gocron.Scheduler
+
+	pending   jobDefinition   // Thông tin job đang được cấu hình trong fluent chain
+	locks     *lockTracker    // Các khóa phân tán đang được giữ
+	events    eventBus        // Các handler sự kiện đã đăng ký
+	chain     middlewareChain // Các middleware áp dụng cho mọi job
+	elector   LeaderElector   // Leader elector (nếu có)
+	registry  *JobRegistry    // Các handler của job theo tên
+	telemetry *telemetry      // Logger và metrics dùng chung với locker và elector
+	logLevel  slog.Level      // Level tối thiểu của các bản ghi
+
+	history    HistoryStore // Lịch sử các lần chạy job (nếu có)
+	instanceID string       // Định danh instance được ghi vào lịch sử
+
+	defaultTimeout time.Duration // Thời gian chạy tối đa mặc định của job, 0 nghĩa là không giới hạn
+
+	syncMu     sync.Mutex               // Bảo vệ configured và overrides
+	configured map[string]configuredJob // Các job được lên lịch từ JobConfig theo tên
+	overrides  map[string]JobSchedule   // Lịch chạy đổi qua Reschedule của các job trong configured
+
+	entriesMu sync.Mutex                 // Bảo vệ entries
+	entries   map[*gocron.Job]*jobEntry  // Các job được lên lịch qua DoContext
+	pauses    PauseStore                 // Trạng thái tạm dừng của job và tag
+	lastPause atomic.Pointer[PauseState] // Trạng thái tạm dừng đọc được gần nhất từ pauses
+	locker    gocron.Locker              // Distributed locker đã được bọc bởi lockTracker (nếu có)
+	triggered sync.WaitGroup             // Các lần chạy được kích hoạt qua RunNow và RunByTag
+	abandoned sync.WaitGroup             // Các lần chạy đã hết thời gian nhưng hàm job chưa trả về
+	orphansMu sync.Mutex
+	orphans   map[string]int // Số lần chạy đã hết thời gian nhưng chưa trả về theo tên job
+
+	runMu      sync.Mutex         // Bảo vệ runCtx và cancelRuns
+	runCtx     context.Context    // Context gốc của các lần chạy job
+	cancelRuns context.CancelFunc // Hủy runCtx khi scheduler dừng
 }
 
 // NewScheduler tạo một đối tượng Manager mới sử dụng gocron làm backend.
-// Nhận tham số config để cấu hình scheduler.
+// Nhận tham số config để cấu hình scheduler, nếu không truyền sẽ sử dụng DefaultConfig().
 func NewScheduler(cfg ...Config) Manager {
-	scheduler := gocron.NewScheduler(time.Local)
-	return &manager{
-		Scheduler: scheduler,
+	if len(cfg) > 0 {
+		return NewSchedulerWithConfig(cfg[0])
 	}
+	return NewSchedulerWithConfig(DefaultConfig())
 }
 
 // NewSchedulerWithConfig tạo một đối tượng Manager mới với cấu hình cụ thể.
+//
+// Múi giờ (Location/Timezone), giới hạn số job chạy đồng thời, TagsUnique và
+// WaitForSchedule trong cfg được áp dụng trực tiếp lên gocron.Scheduler.
+// Nếu Timezone không hợp lệ, scheduler sử dụng time.Local; nếu LogLevel không hợp lệ,
+// level "info" được sử dụng. Gọi cfg.Validate() trước để phát hiện lỗi cấu hình.
 func NewSchedulerWithConfig(cfg Config) Manager {
-	scheduler := gocron.NewScheduler(time.Local)
-	return &manager{
-		Scheduler: scheduler,
+	location, err := cfg.GetLocation()
+	if err != nil {
+		location = time.Local
+	}
+
+	scheduler := gocron.NewScheduler(location)
+
+	if cfg.MaxConcurrentJobs > 0 {
+		mode := gocron.RescheduleMode
+		if cfg.LimitMode == LimitModeWait {
+			mode = gocron.WaitMode
+		}
+		scheduler.SetMaxConcurrentJobs(cfg.MaxConcurrentJobs, mode)
 	}
+
+	if cfg.TagsUnique {
+		scheduler.TagsUnique()
+	}
+
+	if cfg.WaitForSchedule {
+		scheduler.WaitForScheduleAll()
+	}
+
+	logLevel, err := cfg.GetLogLevel()
+	if err != nil {
+		logLevel = slog.LevelInfo
+	}
+
+	m := &manager{
+		Scheduler:      scheduler,
+		locks:          newLockTracker(),
+		registry:       NewJobRegistry(),
+		telemetry:      newTelemetry(withLevel(slog.Default(), logLevel)),
+		logLevel:       logLevel,
+		configured:     make(map[string]configuredJob),
+		overrides:      make(map[string]JobSchedule),
+		entries:        make(map[*gocron.Job]*jobEntry),
+		orphans:        make(map[string]int),
+		pauses:         NewMemoryPauseStore(),
+		defaultTimeout: time.Duration(cfg.DefaultTimeout) * time.Second,
+		instanceID:     resolveInstanceID(cfg.Options.InstanceID),
+	}
+	m.locks.telemetry = m.telemetry
+	m.locks.paused = m.jobPaused
+	m.events.subscribe(m.logEvent, m.recordEvent)
+	return m
 }
 
 // Every tạo một công việc mới với khoảng thời gian được chỉ định.
 func (m *manager) Every(interval interface{}) Manager {
 	m.Scheduler.Every(interval)
+	m.pending.schedule = fmt.Sprintf("every %v", interval)
 	return m
 }
 
 // Second chỉ định đơn vị thời gian là giây (đơn lẻ).
 func (m *manager) Second() Manager {
 	m.Scheduler.Second()
+	m.pending.schedule += " second"
 	return m
 }
 
 // Seconds chỉ định đơn vị thời gian là giây.
 func (m *manager) Seconds() Manager {
 	m.Scheduler.Seconds()
+	m.pending.schedule += " seconds"
 	return m
 }
 
 // Minutes chỉ định đơn vị thời gian là phút.
 func (m *manager) Minutes() Manager {
 	m.Scheduler.Minutes()
+	m.pending.schedule += " minutes"
 	return m
 }
 
 // Hours chỉ định đơn vị thời gian là giờ.
 func (m *manager) Hours() Manager {
 	m.Scheduler.Hours()
+	m.pending.schedule += " hours"
 	return m
 }
 
 // Days chỉ định đơn vị thời gian là ngày.
 func (m *manager) Days() Manager {
 	m.Scheduler.Days()
+	m.pending.schedule += " days"
 	return m
 }
 
 // Weeks chỉ định đơn vị thời gian là tuần.
 func (m *manager) Weeks() Manager {
 	m.Scheduler.Weeks()
+	m.pending.schedule += " weeks"
 	return m
 }
 
 // At chỉ định thời điểm trong ngày để chạy công việc.
 func (m *manager) At(time string) Manager {
 	m.Scheduler.At(time)
+	m.pending.schedule += " at " + time
 	return m
 }
 
@@ -183,45 +428,157 @@ func (m *manager) StartAt(startTime time.Time) Manager {
 // Cron thiết lập biểu thức cron cho công việc.
 func (m *manager) Cron(cronExpression string) Manager {
 	m.Scheduler.Cron(cronExpression)
+	m.pending.schedule = "cron " + cronExpression
 	return m
 }
 
 // CronWithSeconds thiết lập biểu thức cron có hỗ trợ giây.
 func (m *manager) CronWithSeconds(cronExpression string) Manager {
 	m.Scheduler.CronWithSeconds(cronExpression)
+	m.pending.schedule = "cron " + cronExpression
 	return m
 }
 
 // Tag đánh dấu công việc với các tag được chỉ định.
 func (m *manager) Tag(tags ...string) Manager {
 	m.Scheduler.Tag(tags...)
+	m.pending.tags = append(m.pending.tags, tags...)
 	return m
 }
 
 // SingletonMode đặt công việc ở chế độ singleton.
+//
+// Chế độ singleton được thực thi bởi runEntry thay vì SingletonMode của gocron, vốn chạy job
+// mà không qua distributed locker và leader elector.
 func (m *manager) SingletonMode() Manager {
-	m.Scheduler.SingletonMode()
+	m.pending.singleton = true
+	return m
+}
+
+// Timeout đặt thời gian chạy tối đa của công việc.
+// Nếu d âm, DoContext trả về ErrInvalidJobTimeout.
+func (m *manager) Timeout(d time.Duration) Manager {
+	if d < 0 {
+		m.pending.err = ErrInvalidJobTimeout
+		return m
+	}
+	m.pending.timeout = d
+	return m
+}
+
+// Retry đặt chính sách thử lại khi công việc thất bại.
+// Nếu policy không hợp lệ, DoContext trả về ErrInvalidRetryPolicy.
+func (m *manager) Retry(policy RetryPolicy) Manager {
+	if err := policy.Validate(); err != nil {
+		m.pending.err = err
+		return m
+	}
+	m.pending.retry = policy
+	return m
+}
+
+// Middleware thêm các JobMiddleware cho công việc đang được cấu hình.
+func (m *manager) Middleware(middleware ...JobMiddleware) Manager {
+	for _, mw := range middleware {
+		if mw != nil {
+			m.pending.middleware = append(m.pending.middleware, mw)
+		}
+	}
 	return m
 }
 
 // Do đặt hàm để thực thi cho công việc.
+//
+// jobFun được gọi với params qua cùng job runner như DoContext. Với hàm variadic, các params
+// còn lại được truyền cho tham số variadic, hoặc được truyền nguyên nếu params cuối cùng là slice
+// của tham số đó. Trả về gocron.ErrNotAFunction nếu jobFun không phải hàm và
+// gocron.ErrWrongParams nếu params không khớp với tham số của jobFun.
 func (m *manager) Do(jobFun interface{}, params ...interface{}) (*gocron.Job, error) {
-	return m.Scheduler.Do(jobFun, params...)
+	fn := reflect.ValueOf(jobFun)
+	for fn.Kind() == reflect.Ptr {
+		fn = fn.Elem()
+	}
+
+	var (
+		in     []reflect.Value
+		sliced bool
+		err    error
+	)
+	if fn.Kind() != reflect.Func {
+		err = gocron.ErrNotAFunction
+	} else {
+		in, sliced, err = funcArgs(fn.Type(), params)
+	}
+	if err != nil {
+		// Hủy job đang được cấu hình trong fluent chain của gocron
+		m.pending = jobDefinition{}
+		_, _ = m.Scheduler.Do(nil)
+		return nil, err
+	}
+
+	if m.pending.name == "" && m.pending.err == nil {
+		// Giữ tên job (và lock key) giống tên hàm gốc như gocron
+		m.Name(functionName(fn.Interface()))
+	}
+
+	return m.DoContext(func(ctx context.Context) error {
+		return callFunc(fn, in, sliced)
+	})
+}
+
+// DoContext đặt hàm nhận context để thực thi cho công việc.
+func (m *manager) DoContext(jobFun JobFunc) (*gocron.Job, error) {
+	def := m.pending
+	m.pending = jobDefinition{}
+
+	if def.err != nil {
+		// Hủy job đang được cấu hình trong fluent chain của gocron
+		_, _ = m.Scheduler.Do(nil)
+		return nil, def.err
+	}
+
+	if def.name == "" {
+		// Giữ tên job (và lock key) giống tên hàm gốc thay vì tên closure bọc bên trong
+		def.name = functionName(jobFun)
+		m.Scheduler.Name(def.name)
+	}
+
+	// Job của gocron được dùng để lấy thời điểm lên lịch của mỗi lần chạy; lần chạy đầu tiên
+	// có thể bắt đầu trước khi Do trả về
+	entry := &jobEntry{fn: jobFun}
+	entry.def.Store(&def)
+	job, err := m.Scheduler.Do(func() error {
+		return m.runScheduled(entry)
+	})
+	if err != nil {
+		return job, err
+	}
+	entry.job.Store(job)
+	m.addEntry(job, entry)
+	return job, nil
+}
+
+// Registry trả về JobRegistry chứa các handler của job theo tên.
+func (m *manager) Registry() *JobRegistry {
+	return m.registry
 }
 
 // Name đặt tên cho công việc đang được cấu hình.
 func (m *manager) Name(name string) Manager {
 	m.Scheduler.Name(name)
+	m.pending.name = name
 	return m
 }
 
 // RemoveByTag xóa các công việc theo tag.
 func (m *manager) RemoveByTag(tag string) error {
+	defer m.scheduledEntries()
 	return m.Scheduler.RemoveByTag(tag)
 }
 
 // RemoveByTags xóa các công việc khớp với TẤT CẢ tags đã chỉ định.
 func (m *manager) RemoveByTags(tags ...string) error {
+	defer m.scheduledEntries()
 	return m.Scheduler.RemoveByTags(tags...)
 }
 
@@ -232,22 +589,49 @@ func (m *manager) FindJobsByTag(tags ...string) ([]*gocron.Job, error) {
 
 // StartAsync bắt đầu scheduler trong một goroutine riêng.
 func (m *manager) StartAsync() {
+	m.runContext()
+	m.startElector()
 	m.Scheduler.StartAsync()
+	m.telemetry.logger().Info("scheduler: started", slog.Int("jobs", m.Scheduler.Len()))
 }
 
 // StartBlocking bắt đầu scheduler và chặn luồng hiện tại.
 func (m *manager) StartBlocking() {
+	m.runContext()
+	m.startElector()
+	m.telemetry.logger().Info("scheduler: started", slog.Int("jobs", m.Scheduler.Len()))
 	m.Scheduler.StartBlocking()
 }
 
 // Stop dừng scheduler.
+//
+// Context của các job đăng ký qua DoContext bị hủy trước, sau đó Stop chờ các job đang chạy
+// trả về, kể cả các lần chạy được kích hoạt qua RunNow và RunByTag. Khác với Shutdown, các job
+// không được chạy tiếp tới khi kết thúc.
 func (m *manager) Stop() {
+	m.cancelRunning()
 	m.Scheduler.Stop()
+	m.triggered.Wait()
+	m.abandoned.Wait()
+	m.stopElector()
+	m.telemetry.logger().Info("scheduler: stopped")
 }
 
 // Clear xóa tất cả các công việc đã đăng ký.
 func (m *manager) Clear() {
+	m.syncMu.Lock()
+	defer m.syncMu.Unlock()
+
+	m.entriesMu.Lock()
 	m.Scheduler.Clear()
+	m.entries = make(map[*gocron.Job]*jobEntry)
+	m.entriesMu.Unlock()
+
+	for name := range m.configured {
+		m.locks.exempt(name, false)
+	}
+	m.configured = make(map[string]configuredJob)
+	m.overrides = make(map[string]JobSchedule)
 }
 
 // GetScheduler trả về đối tượng scheduler gốc của gocron.
@@ -256,11 +640,98 @@ func (m *manager) GetScheduler() *gocron.Scheduler {
 }
 
 // WithDistributedLocker thiết lập distributed locker cho scheduler.
+// Các khóa lấy được qua locker được ghi nhận để hủy job khi khóa bị mất.
 func (m *manager) WithDistributedLocker(locker gocron.Locker) Manager {
-	m.Scheduler.WithDistributedLocker(locker)
+	if l, ok := locker.(instrumented); ok {
+		l.setTelemetry(m.telemetry)
+	}
+	m.locker = m.locks.wrap(locker)
+	m.Scheduler.WithDistributedLocker(m.locker)
+	return m
+}
+
+// WithLeaderElector thiết lập leader elector cho scheduler.
+func (m *manager) WithLeaderElector(elector LeaderElector) Manager {
+	m.elector = elector
+	if l, ok := elector.(instrumented); ok {
+		l.setTelemetry(m.telemetry)
+	}
+	m.Scheduler.WithDistributedElector(elector)
+	return m
+}
+
+// WithLogger thiết lập logger cho scheduler.
+func (m *manager) WithLogger(logger *slog.Logger) Manager {
+	m.telemetry.setLogger(withLevel(logger, m.logLevel))
+	return m
+}
+
+// WithMetrics thiết lập MetricsCollector cho scheduler.
+func (m *manager) WithMetrics(collector MetricsCollector) Manager {
+	m.telemetry.setMetrics(collector)
 	return m
 }
 
+// WithTracerProvider thiết lập TracerProvider cho scheduler.
+func (m *manager) WithTracerProvider(provider trace.TracerProvider) Manager {
+	m.telemetry.setTracerProvider(provider)
+	return m
+}
+
+// WithHistory thiết lập HistoryStore cho scheduler.
+func (m *manager) WithHistory(store HistoryStore) Manager {
+	m.history = store
+	return m
+}
+
+// WithPauseStore thiết lập PauseStore cho scheduler.
+func (m *manager) WithPauseStore(store PauseStore) Manager {
+	if store != nil {
+		m.pauses = store
+	}
+	return m
+}
+
+// History trả về các lần chạy gần nhất của job.
+func (m *manager) History(ctx context.Context, job string, limit, offset int) ([]JobRun, error) {
+	if m.history == nil {
+		return nil, ErrHistoryNotConfigured
+	}
+	if limit <= 0 {
+		limit = DefaultHistoryPageSize
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return m.history.List(ctx, job, limit, offset)
+}
+
+// startElector khởi động leader elector (nếu có) và phát sự kiện khi leadership thay đổi.
+func (m *manager) startElector() {
+	if m.elector == nil {
+		return
+	}
+	m.elector.Start(func(isLeader bool) {
+		eventType := EventLeadershipLost
+		if isLeader {
+			eventType = EventLeadershipAcquired
+		}
+		m.events.emit(Event{Type: eventType})
+	})
+}
+
+// stopElector dừng leader elector (nếu có) và từ bỏ leadership.
+func (m *manager) stopElector() {
+	if m.elector == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := m.elector.Stop(ctx); err != nil {
+		m.telemetry.logger().Warn("scheduler: failed to release leadership", slog.Any("error", err))
+	}
+}
+
 // RegisterEventListeners đăng ký các listener cho các sự kiện.
 func (m *manager) RegisterEventListeners(eventListeners ...gocron.EventListener) {
 	m.Scheduler.RegisterEventListeners(eventListeners...)
@@ -270,3 +741,13 @@ func (m *manager) RegisterEventListeners(eventListeners ...gocron.EventListener)
 func (m *manager) IsRunning() bool {
 	return m.Scheduler.IsRunning()
 }
+
+// OnEvent đăng ký các handler nhận sự kiện vòng đời của job.
+func (m *manager) OnEvent(handlers ...EventHandler) {
+	m.events.subscribe(handlers...)
+}
+
+// Use đăng ký các JobMiddleware áp dụng cho mọi công việc.
+func (m *manager) Use(middleware ...JobMiddleware) {
+	m.chain.use(middleware...)
+}
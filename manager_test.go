@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -18,6 +19,74 @@ func TestNewScheduler(t *testing.T) {
 	var _ Manager = scheduler
 }
 
+func TestNewSchedulerWithConfigTimezone(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Timezone = "Asia/Ho_Chi_Minh"
+
+	scheduler := NewSchedulerWithConfig(cfg)
+	if got := scheduler.GetScheduler().Location().String(); got != "Asia/Ho_Chi_Minh" {
+		t.Fatalf("Expected location Asia/Ho_Chi_Minh, got %s", got)
+	}
+
+	// NewScheduler cũng phải áp dụng config được truyền vào
+	cfg.Location = time.UTC
+	scheduler = NewScheduler(cfg)
+	if got := scheduler.GetScheduler().Location(); got != time.UTC {
+		t.Fatalf("Expected location UTC, got %s", got)
+	}
+}
+
+func TestNewSchedulerWithConfigInvalidTimezoneFallsBackToLocal(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Timezone = "Invalid/Zone"
+
+	scheduler := NewSchedulerWithConfig(cfg)
+	if got := scheduler.GetScheduler().Location(); got != time.Local {
+		t.Fatalf("Expected location Local, got %s", got)
+	}
+}
+
+func TestNewSchedulerWithConfigTagsUnique(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TagsUnique = true
+
+	scheduler := NewSchedulerWithConfig(cfg)
+
+	if _, err := scheduler.Every(1).Second().Tag("unique").Do(func() {}); err != nil {
+		t.Fatalf("Failed to create first job: %v", err)
+	}
+
+	if _, err := scheduler.Every(1).Second().Tag("unique").Do(func() {}); err == nil {
+		t.Fatal("Expected error when reusing a unique tag")
+	}
+}
+
+func TestNewSchedulerWithConfigWaitForSchedule(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WaitForSchedule = true
+	cfg.MaxConcurrentJobs = 2
+	cfg.LimitMode = LimitModeWait
+
+	scheduler := NewSchedulerWithConfig(cfg)
+
+	ran := make(chan struct{}, 1)
+	_, err := scheduler.Every(1).Hours().Do(func() {
+		ran <- struct{}{}
+	})
+	if err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case <-ran:
+		t.Fatal("Job should wait for its first schedule instead of running immediately")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
 func TestSchedulerFluentInterface(t *testing.T) {
 	scheduler := NewScheduler()
 
@@ -185,6 +254,35 @@ func TestSchedulerClear(t *testing.T) {
 	}
 }
 
+func TestSchedulerClearRemovesEntries(t *testing.T) {
+	scheduler := NewScheduler()
+	_ = scheduler.Registry().Register("sync", func(ctx context.Context) error { return nil })
+
+	if _, err := scheduler.Every(1).Hours().Name("report").Do(func() {}); err != nil {
+		t.Fatalf("Failed to create job: %v", err)
+	}
+	job := JobConfig{Name: "sync", Interval: "1h", Lock: new(bool)}
+	if _, err := scheduler.ScheduleJob(job); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.Clear()
+
+	m := scheduler.(*manager)
+	m.entriesMu.Lock()
+	entries := len(m.entries)
+	m.entriesMu.Unlock()
+	if entries != 0 {
+		t.Errorf("Expected 0 entries after Clear(), got %d", entries)
+	}
+	m.locks.mu.Lock()
+	exempt := len(m.locks.unlocked)
+	m.locks.mu.Unlock()
+	if exempt != 0 {
+		t.Errorf("Expected no lock exemptions after Clear(), got %d", exempt)
+	}
+}
+
 func TestSchedulerWithDistributedLocker(t *testing.T) {
 	scheduler := NewScheduler()
 
@@ -227,3 +325,162 @@ type mockLock struct{}
 func (m *mockLock) Unlock(ctx context.Context) error {
 	return nil
 }
+
+func TestSchedulerScheduleJob(t *testing.T) {
+	scheduler := NewScheduler()
+
+	ran := make(chan JobInfo, 1)
+	err := scheduler.Registry().Register("orders.sync", func(ctx context.Context) error {
+		info, _ := JobInfoFromContext(ctx)
+		ran <- info
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register handler: %v", err)
+	}
+
+	job, err := scheduler.ScheduleJob(JobConfig{
+		Name:     "sync",
+		Handler:  "orders.sync",
+		Interval: "1h",
+		Tags:     []string{"orders"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if job.GetName() != "sync" {
+		t.Errorf("Expected job name sync, got %q", job.GetName())
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case info := <-ran:
+		if info.Name != "sync" || len(info.Tags) != 1 || info.Tags[0] != "orders" {
+			t.Errorf("Unexpected job info %+v", info)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected handler to run")
+	}
+}
+
+func TestSchedulerScheduleJobSchedules(t *testing.T) {
+	scheduler := NewScheduler()
+
+	jobs := []JobConfig{
+		{Name: "cron", Cron: "*/5 * * * *"},
+		{Name: "cron-seconds", Cron: "*/30 * * * * *"},
+		{Name: "daily", At: "08:30"},
+	}
+	for _, cfg := range jobs {
+		if _, err := scheduler.ScheduleJob(cfg); err != nil {
+			t.Errorf("Failed to schedule job %s: %v", cfg.Name, err)
+		}
+	}
+
+	if _, err := scheduler.ScheduleJob(JobConfig{Name: "invalid"}); err != ErrInvalidJobSchedule {
+		t.Errorf("Expected ErrInvalidJobSchedule, got %v", err)
+	}
+}
+
+func TestSchedulerScheduleJobMissingHandler(t *testing.T) {
+	scheduler := NewScheduler()
+
+	failed := make(chan error, 1)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobFailed {
+			failed <- event.Err
+		}
+	})
+
+	if _, err := scheduler.ScheduleJob(JobConfig{Name: "missing", Interval: "1h"}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case err := <-failed:
+		if !errors.Is(err, ErrJobHandlerNotFound) {
+			t.Errorf("Expected ErrJobHandlerNotFound, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to fail")
+	}
+}
+
+func TestSchedulerScheduleJobWithoutLock(t *testing.T) {
+	locker := &recordingLocker{lock: &notifyingLock{lost: make(chan struct{})}}
+	scheduler := NewScheduler().WithDistributedLocker(locker)
+
+	ran := make(chan struct{}, 1)
+	_ = scheduler.Registry().Register("report", func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	})
+
+	noLock := false
+	if _, err := scheduler.ScheduleJob(JobConfig{Name: "report", Interval: "1h", Lock: &noLock}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected handler to run")
+	}
+
+	locker.mu.Lock()
+	defer locker.mu.Unlock()
+	if len(locker.keys) != 0 {
+		t.Errorf("Expected no lock to be requested, got %v", locker.keys)
+	}
+}
+
+func TestSchedulerDoHandler(t *testing.T) {
+	scheduler := NewScheduler()
+
+	ran := make(chan string, 2)
+	_ = scheduler.Registry().Register("orders.sync", func(ctx context.Context) error {
+		info, _ := JobInfoFromContext(ctx)
+		ran <- info.Name
+		return nil
+	})
+
+	job, err := scheduler.Every(1).Hours().DoHandler("orders.sync")
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if job.GetName() != "orders.sync" {
+		t.Errorf("Expected job name to default to handler name, got %q", job.GetName())
+	}
+
+	job, err = scheduler.Every(1).Hours().Name("custom").DoHandler("orders.sync")
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if job.GetName() != "custom" {
+		t.Errorf("Expected job name custom, got %q", job.GetName())
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	names := map[string]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case name := <-ran:
+			names[name] = true
+		case <-time.After(2 * time.Second):
+			t.Fatal("Expected both jobs to run")
+		}
+	}
+	if !names["orders.sync"] || !names["custom"] {
+		t.Errorf("Unexpected job names %v", names)
+	}
+}
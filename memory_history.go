@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryHistoryStore triển khai HistoryStore trong bộ nhớ của process, với một ring buffer
+// cho mỗi job giữ tối đa maxEntries lần chạy gần nhất.
+type memoryHistoryStore struct {
+	mu         sync.RWMutex
+	maxEntries int
+	runs       map[string]*runRing
+}
+
+// runRing là ring buffer các lần chạy của một job.
+type runRing struct {
+	runs  []JobRun
+	next  int // Vị trí ghi tiếp theo
+	count int // Số lần chạy đang được giữ
+}
+
+// NewMemoryHistoryStore tạo HistoryStore lưu lịch sử trong bộ nhớ của process, giữ tối đa
+// maxEntries lần chạy gần nhất cho mỗi job (0 hoặc âm sẽ dùng DefaultHistoryMaxEntries).
+//
+// Lịch sử bị mất khi process kết thúc và không được chia sẻ giữa các instance; dùng
+// NewSQLHistoryStore hoặc NewRedisHistoryStore khi chạy nhiều instance.
+func NewMemoryHistoryStore(maxEntries int) HistoryStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultHistoryMaxEntries
+	}
+	return &memoryHistoryStore{
+		maxEntries: maxEntries,
+		runs:       make(map[string]*runRing),
+	}
+}
+
+// Record triển khai HistoryStore, ghi đè lần chạy cũ nhất khi ring buffer của job đã đầy.
+func (s *memoryHistoryStore) Record(ctx context.Context, run JobRun) error {
+	run.Tags = append([]string(nil), run.Tags...)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ring, ok := s.runs[run.JobName]
+	if !ok {
+		ring = &runRing{runs: make([]JobRun, s.maxEntries)}
+		s.runs[run.JobName] = ring
+	}
+
+	ring.runs[ring.next] = run
+	ring.next = (ring.next + 1) % len(ring.runs)
+	if ring.count < len(ring.runs) {
+		ring.count++
+	}
+	return nil
+}
+
+// List triển khai HistoryStore.
+func (s *memoryHistoryStore) List(ctx context.Context, job string, limit, offset int) ([]JobRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if offset < 0 {
+		offset = 0
+	}
+	ring, ok := s.runs[job]
+	if !ok || limit <= 0 || offset >= ring.count {
+		return []JobRun{}, nil
+	}
+
+	n := ring.count - offset
+	if limit < n {
+		n = limit
+	}
+
+	runs := make([]JobRun, 0, n)
+	size := len(ring.runs)
+	for i := 0; i < n; i++ {
+		// Lần chạy mới nhất nằm ngay trước vị trí ghi tiếp theo
+		index := (ring.next - 1 - offset - i + 2*size) % size
+		run := ring.runs[index]
+		run.Tags = append([]string(nil), run.Tags...)
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
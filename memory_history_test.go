@@ -0,0 +1,113 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// recordTestRuns ghi n lần chạy của job vào store, lần chạy thứ i có Attempt là i.
+func recordTestRuns(t *testing.T, store HistoryStore, job string, n int) {
+	t.Helper()
+	start := time.Now().Add(-time.Hour)
+	for i := 1; i <= n; i++ {
+		startedAt := start.Add(time.Duration(i) * time.Second)
+		run := JobRun{
+			JobName:     job,
+			Tags:        []string{"reports", "daily"},
+			ScheduledAt: startedAt,
+			StartedAt:   startedAt,
+			FinishedAt:  startedAt.Add(250 * time.Millisecond),
+			InstanceID:  "node-1",
+			Outcome:     OutcomeSucceeded,
+			Attempt:     i,
+		}
+		if err := store.Record(context.Background(), run); err != nil {
+			t.Fatalf("Failed to record run: %v", err)
+		}
+	}
+}
+
+// attempts trả về Attempt của các lần chạy theo thứ tự.
+func attempts(runs []JobRun) string {
+	values := make([]int, len(runs))
+	for i, run := range runs {
+		values[i] = run.Attempt
+	}
+	return fmt.Sprint(values)
+}
+
+func TestMemoryHistoryStoreListsNewestFirst(t *testing.T) {
+	store := NewMemoryHistoryStore(10)
+	recordTestRuns(t, store, "report", 5)
+	recordTestRuns(t, store, "other", 2)
+
+	tests := []struct {
+		limit, offset int
+		expected      string
+	}{
+		{10, 0, "[5 4 3 2 1]"},
+		{2, 0, "[5 4]"},
+		{2, 2, "[3 2]"},
+		{2, 4, "[1]"},
+		{2, 5, "[]"},
+		{0, 0, "[]"},
+	}
+	for _, tt := range tests {
+		runs, err := store.List(context.Background(), "report", tt.limit, tt.offset)
+		if err != nil {
+			t.Fatalf("Failed to list runs: %v", err)
+		}
+		if got := attempts(runs); got != tt.expected {
+			t.Errorf("List(limit=%d, offset=%d) = %s, expected %s", tt.limit, tt.offset, got, tt.expected)
+		}
+	}
+
+	runs, _ := store.List(context.Background(), "missing", 10, 0)
+	if runs == nil || len(runs) != 0 {
+		t.Errorf("Expected empty runs for unknown job, got %v", runs)
+	}
+}
+
+func TestMemoryHistoryStoreKeepsMaxEntriesPerJob(t *testing.T) {
+	store := NewMemoryHistoryStore(3)
+	recordTestRuns(t, store, "report", 7)
+	recordTestRuns(t, store, "other", 1)
+
+	runs, err := store.List(context.Background(), "report", 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list runs: %v", err)
+	}
+	if got := attempts(runs); got != "[7 6 5]" {
+		t.Errorf("Expected the 3 newest runs, got %s", got)
+	}
+
+	runs, _ = store.List(context.Background(), "report", 2, 1)
+	if got := attempts(runs); got != "[6 5]" {
+		t.Errorf("Expected paginated runs after wrap around, got %s", got)
+	}
+
+	runs, _ = store.List(context.Background(), "other", 10, 0)
+	if len(runs) != 1 {
+		t.Errorf("Expected runs of other jobs to be kept, got %d", len(runs))
+	}
+}
+
+func TestMemoryHistoryStoreCopiesTags(t *testing.T) {
+	store := NewMemoryHistoryStore(0)
+
+	tags := []string{"reports"}
+	if err := store.Record(context.Background(), JobRun{JobName: "report", Tags: tags}); err != nil {
+		t.Fatalf("Failed to record run: %v", err)
+	}
+	tags[0] = "changed"
+
+	runs, _ := store.List(context.Background(), "report", 1, 0)
+	runs[0].Tags[0] = "mutated"
+
+	runs, _ = store.List(context.Background(), "report", 1, 0)
+	if runs[0].Tags[0] != "reports" {
+		t.Errorf("Expected stored tags to be isolated, got %v", runs[0].Tags)
+	}
+}
@@ -0,0 +1,195 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// MemoryLockStore lưu trữ các khóa trong bộ nhớ của process.
+//
+// Một MemoryLockStore có thể được dùng chung giữa nhiều MemoryLocker (và nhiều Manager)
+// trong cùng process để mô phỏng nhiều node tranh chấp cùng một khóa, ví dụ trong unit test.
+type MemoryLockStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryLockEntry
+}
+
+// memoryLockEntry là một khóa đang được giữ trong MemoryLockStore.
+type memoryLockEntry struct {
+	token     string
+	expiresAt time.Time
+}
+
+// NewMemoryLockStore tạo một MemoryLockStore rỗng.
+func NewMemoryLockStore() *MemoryLockStore {
+	return &MemoryLockStore{
+		entries: make(map[string]memoryLockEntry),
+	}
+}
+
+// acquire lấy khóa cho token nếu khóa chưa được giữ hoặc đã hết hạn.
+func (s *MemoryLockStore) acquire(key, token string, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := s.entries[key]; ok && now.Before(entry.expiresAt) {
+		return false
+	}
+	s.entries[key] = memoryLockEntry{token: token, expiresAt: now.Add(ttl)}
+	return true
+}
+
+// renew gia hạn khóa nếu khóa vẫn thuộc về token và chưa hết hạn.
+func (s *MemoryLockStore) renew(key, token string, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := s.entries[key]
+	if !ok || entry.token != token || !now.Before(entry.expiresAt) {
+		return false
+	}
+	entry.expiresAt = now.Add(ttl)
+	s.entries[key] = entry
+	return true
+}
+
+// release xóa khóa nếu khóa vẫn thuộc về token và chưa hết hạn.
+func (s *MemoryLockStore) release(key, token string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.entries[key]
+	if !ok || entry.token != token {
+		return false
+	}
+	delete(s.entries, key)
+	return time.Now().Before(entry.expiresAt)
+}
+
+// memoryLocker triển khai gocron.Locker interface sử dụng MemoryLockStore làm backend.
+type memoryLocker struct {
+	store      *MemoryLockStore
+	options    RedisLockerOptionsTime
+	instanceID string
+	telemetry  *telemetry
+}
+
+// memoryLock triển khai gocron.Lock và LockLostNotifier cho memoryLocker.
+type memoryLock struct {
+	*lease
+
+	locker *memoryLocker
+	key    string
+	token  string
+}
+
+// NewMemoryLocker tạo một Locker trong bộ nhớ với cùng ngữ nghĩa như Redis Locker:
+// khóa có thời hạn, tự động gia hạn, thử lại khi bị chiếm và owner token cho mỗi lần lấy khóa.
+//
+// Các locker dùng chung store sẽ tranh chấp cùng một tập khóa. Nếu store là nil, locker sử
+// dụng một store riêng. Tùy chọn sử dụng RedisLockerOptions (KeyPrefix, LockDuration,
+// MaxRetries, RetryDelay, InstanceID).
+//
+// Example:
+//
+//	store := scheduler.NewMemoryLockStore()
+//	nodeA, _ := scheduler.NewMemoryLocker(store)
+//	nodeB, _ := scheduler.NewMemoryLocker(store)
+//	schedA.WithDistributedLocker(nodeA)
+//	schedB.WithDistributedLocker(nodeB)
+func NewMemoryLocker(store *MemoryLockStore, opts ...RedisLockerOptions) (gocron.Locker, error) {
+	if store == nil {
+		store = NewMemoryLockStore()
+	}
+
+	// Sử dụng tùy chọn mặc định
+	options := DefaultRedisLockerOptions()
+
+	// Nếu có tùy chọn được cung cấp, sử dụng chúng
+	if len(opts) > 0 {
+		options = opts[0]
+
+		// Validate các giá trị options
+		if err := validateRedisLockerOptions(options); err != nil {
+			return nil, err
+		}
+	}
+
+	timeOptions := options.ToTimeDuration()
+
+	return &memoryLocker{
+		store:      store,
+		options:    timeOptions,
+		instanceID: resolveInstanceID(timeOptions.InstanceID),
+	}, nil
+}
+
+// setTelemetry triển khai instrumented.
+func (m *memoryLocker) setTelemetry(telemetry *telemetry) {
+	m.telemetry = telemetry
+}
+
+// Lock triển khai phương thức Lock của gocron.Locker interface.
+func (m *memoryLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
+	fullKey := m.options.KeyPrefix + key
+	retries := 0
+
+	token, err := newLockToken(m.instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if m.store.acquire(fullKey, token, m.options.LockDuration) {
+			lock := &memoryLock{
+				locker: m,
+				key:    fullKey,
+				token:  token,
+			}
+
+			// Bắt đầu quá trình tự động gia hạn khóa
+			lock.lease = newLease(key, m.options.LockDuration, lock.renew, m.telemetry)
+
+			return lock, nil
+		}
+
+		// Nếu đã thử tối đa số lần
+		if retries >= m.options.MaxRetries {
+			return nil, ErrFailedToAcquireLock
+		}
+
+		// Chờ một khoảng thời gian trước khi thử lại
+		select {
+		case <-time.After(m.options.RetryDelay):
+			retries++
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// renew gia hạn khóa nếu khóa vẫn thuộc về owner token hiện tại.
+func (l *memoryLock) renew(ctx context.Context) error {
+	if !l.locker.store.renew(l.key, l.token, l.locker.options.LockDuration) {
+		return ErrLockOwnershipLost
+	}
+	return nil
+}
+
+// Unlock triển khai phương thức Unlock của gocron.Lock interface.
+//
+// Giống Redis Locker, Unlock trả về ErrLockOwnershipLost nếu khóa đã hết hạn
+// hoặc đã bị locker khác lấy.
+func (l *memoryLock) Unlock(ctx context.Context) error {
+	l.stop()
+
+	if !l.locker.store.release(l.key, l.token) {
+		return ErrLockOwnershipLost
+	}
+	return nil
+}
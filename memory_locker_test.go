@@ -0,0 +1,157 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testMemoryLockerOptions trả về tùy chọn với thời hạn ngắn cho test.
+func testMemoryLockerOptions(instanceID string) RedisLockerOptions {
+	return RedisLockerOptions{
+		KeyPrefix:    "scheduler_lock:",
+		LockDuration: 1,
+		MaxRetries:   0,
+		RetryDelay:   10,
+		InstanceID:   instanceID,
+	}
+}
+
+func TestNewMemoryLockerWithInvalidOptions(t *testing.T) {
+	_, err := NewMemoryLocker(nil, RedisLockerOptions{KeyPrefix: "test:", LockDuration: -1, MaxRetries: 1, RetryDelay: 10})
+	if err != ErrInvalidLockDuration {
+		t.Errorf("Expected ErrInvalidLockDuration, got %v", err)
+	}
+}
+
+func TestMemoryLockerContentionBetweenLockers(t *testing.T) {
+	store := NewMemoryLockStore()
+	nodeA, _ := NewMemoryLocker(store, testMemoryLockerOptions("node-a"))
+	nodeB, _ := NewMemoryLocker(store, testMemoryLockerOptions("node-b"))
+
+	lock, err := nodeA.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	token := lock.(*memoryLock).token
+	if !strings.HasPrefix(token, "node-a:") {
+		t.Errorf("Expected token to start with instance id, got %q", token)
+	}
+
+	if _, err := nodeB.Lock(context.Background(), "job"); err != ErrFailedToAcquireLock {
+		t.Errorf("Expected ErrFailedToAcquireLock, got %v", err)
+	}
+
+	if err := lock.Unlock(context.Background()); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+
+	lockB, err := nodeB.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Expected node-b to acquire released lock, got %v", err)
+	}
+	_ = lockB.Unlock(context.Background())
+}
+
+func TestMemoryLockerRetriesUntilReleased(t *testing.T) {
+	store := NewMemoryLockStore()
+	nodeA, _ := NewMemoryLocker(store, testMemoryLockerOptions("node-a"))
+
+	opts := testMemoryLockerOptions("node-b")
+	opts.MaxRetries = 50
+	nodeB, _ := NewMemoryLocker(store, opts)
+
+	lock, err := nodeA.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	time.AfterFunc(50*time.Millisecond, func() { _ = lock.Unlock(context.Background()) })
+
+	lockB, err := nodeB.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Expected retry to acquire lock after release, got %v", err)
+	}
+	_ = lockB.Unlock(context.Background())
+}
+
+func TestMemoryLockRenewKeepsLockAlive(t *testing.T) {
+	store := NewMemoryLockStore()
+	nodeA, _ := NewMemoryLocker(store, testMemoryLockerOptions("node-a"))
+	nodeB, _ := NewMemoryLocker(store, testMemoryLockerOptions("node-b"))
+
+	lock, err := nodeA.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer lock.Unlock(context.Background())
+
+	// Vượt quá LockDuration, khóa vẫn phải được giữ nhờ gia hạn
+	time.Sleep(1500 * time.Millisecond)
+
+	if _, err := nodeB.Lock(context.Background(), "job"); err != ErrFailedToAcquireLock {
+		t.Errorf("Expected renewed lock to stay held, got %v", err)
+	}
+}
+
+func TestMemoryLockLostAndUnlockAfterTakeover(t *testing.T) {
+	store := NewMemoryLockStore()
+	nodeA, _ := NewMemoryLocker(store, testMemoryLockerOptions("node-a"))
+
+	lock, err := nodeA.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	// Mô phỏng khóa hết hạn và bị node khác lấy
+	store.mu.Lock()
+	store.entries["scheduler_lock:job"] = memoryLockEntry{token: "node-b:token", expiresAt: time.Now().Add(time.Minute)}
+	store.mu.Unlock()
+
+	notifier := lock.(LockLostNotifier)
+	select {
+	case <-notifier.Lost():
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected lost signal after takeover")
+	}
+	if !errors.Is(notifier.Err(), ErrLockOwnershipLost) {
+		t.Errorf("Expected ErrLockOwnershipLost, got %v", notifier.Err())
+	}
+
+	if err := lock.Unlock(context.Background()); !errors.Is(err, ErrLockOwnershipLost) {
+		t.Errorf("Expected ErrLockOwnershipLost on unlock, got %v", err)
+	}
+	if _, ok := store.entries["scheduler_lock:job"]; !ok {
+		t.Error("Unlock must not delete the other owner's lock")
+	}
+}
+
+func TestMemoryLockerSharedBetweenManagers(t *testing.T) {
+	store := NewMemoryLockStore()
+	var runs int32
+
+	for _, instanceID := range []string{"node-a", "node-b"} {
+		locker, err := NewMemoryLocker(store, testMemoryLockerOptions(instanceID))
+		if err != nil {
+			t.Fatalf("Failed to create memory locker: %v", err)
+		}
+
+		scheduler := NewScheduler().WithDistributedLocker(locker)
+		if _, err := scheduler.Every(1).Hours().Name("report").Do(func() {
+			atomic.AddInt32(&runs, 1)
+		}); err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+		scheduler.StartAsync()
+		defer scheduler.Stop()
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("Expected job to run once across managers, got %d", got)
+	}
+}
@@ -0,0 +1,52 @@
+package scheduler
+
+import "time"
+
+// MetricsCollector thu thập metrics của job và khóa phân tán.
+//
+// Manager gọi MetricsCollector đồng bộ trên goroutine của job hoặc locker, vì vậy các phương thức
+// phải an toàn khi gọi đồng thời và không nên block. Xem NewPrometheusCollector cho implementation
+// với Prometheus.
+type MetricsCollector interface {
+	// JobStarted được gọi khi một lần chạy (hoặc thử lại) của job bắt đầu.
+	JobStarted(job string, tags []string)
+
+	// JobFinished được gọi khi một lần chạy kết thúc, err là nil nếu lần chạy thành công.
+	JobFinished(job string, tags []string, duration time.Duration, err error)
+
+	// JobPanicked được gọi khi hàm job panic, trước JobFinished của lần chạy thất bại đó.
+	JobPanicked(job string, tags []string)
+
+	// JobSkipped được gọi khi lần chạy bị bỏ qua vì khóa phân tán của job đang được giữ
+	// bởi instance khác hoặc không lấy được khóa.
+	JobSkipped(job string)
+
+	// LockAcquired được gọi sau mỗi lần lấy khóa phân tán với thời gian chờ lấy khóa,
+	// acquired là false nếu không lấy được khóa.
+	LockAcquired(key string, latency time.Duration, acquired bool)
+
+	// LockRenewFailed được gọi khi một lần gia hạn khóa phân tán thất bại.
+	LockRenewFailed(key string)
+}
+
+// noopMetrics là MetricsCollector không thu thập metrics.
+type noopMetrics struct{}
+
+func (noopMetrics) JobStarted(string, []string)                        {}
+func (noopMetrics) JobFinished(string, []string, time.Duration, error) {}
+func (noopMetrics) JobPanicked(string, []string)                       {}
+func (noopMetrics) JobSkipped(string)                                  {}
+func (noopMetrics) LockAcquired(string, time.Duration, bool)           {}
+func (noopMetrics) LockRenewFailed(string)                             {}
+
+// recordEvent ghi nhận metrics cho các sự kiện vòng đời của job.
+func (m *manager) recordEvent(event Event) {
+	switch event.Type {
+	case EventJobStarted:
+		m.telemetry.metrics().JobStarted(event.JobName, event.Tags)
+	case EventJobPanicked:
+		m.telemetry.metrics().JobPanicked(event.JobName, event.Tags)
+	case EventJobSucceeded, EventJobFailed:
+		m.telemetry.metrics().JobFinished(event.JobName, event.Tags, event.Duration, event.Err)
+	}
+}
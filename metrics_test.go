@@ -0,0 +1,201 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMetrics là MetricsCollector ghi lại các lời gọi.
+type recordingMetrics struct {
+	mu            sync.Mutex
+	started       []string
+	finished      map[string]error
+	panicked      []string
+	skipped       []string
+	acquired      map[string]bool
+	renewFailures []string
+}
+
+func (r *recordingMetrics) JobStarted(job string, tags []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = append(r.started, job)
+}
+
+func (r *recordingMetrics) JobFinished(job string, tags []string, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.finished == nil {
+		r.finished = make(map[string]error)
+	}
+	r.finished[job] = err
+}
+
+func (r *recordingMetrics) JobPanicked(job string, tags []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.panicked = append(r.panicked, job)
+}
+
+func (r *recordingMetrics) JobSkipped(job string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.skipped = append(r.skipped, job)
+}
+
+func (r *recordingMetrics) LockAcquired(key string, latency time.Duration, acquired bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.acquired == nil {
+		r.acquired = make(map[string]bool)
+	}
+	r.acquired[key] = acquired
+}
+
+func (r *recordingMetrics) LockRenewFailed(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.renewFailures = append(r.renewFailures, key)
+}
+
+func TestManagerRecordsMetrics(t *testing.T) {
+	store := NewMemoryLockStore()
+	locker, err := NewMemoryLocker(store)
+	if err != nil {
+		t.Fatalf("Failed to create locker: %v", err)
+	}
+
+	metrics := &recordingMetrics{}
+	scheduler := NewScheduler().WithMetrics(metrics).WithDistributedLocker(locker)
+
+	// Khóa của job "held" đang được giữ bởi instance khác
+	other, _ := NewMemoryLocker(store)
+	held, err := other.Lock(context.Background(), "held")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer held.Unlock(context.Background())
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobSucceeded || event.Type == EventJobFailed {
+			wg.Done()
+		}
+	})
+
+	errFailed := errors.New("failed")
+	if _, err := scheduler.Every(1).Hours().Name("ok").DoContext(func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if _, err := scheduler.Every(1).Hours().Name("bad").DoContext(func(ctx context.Context) error { return errFailed }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if _, err := scheduler.Every(1).Hours().Name("held").DoContext(func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected jobs to run")
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		metrics.mu.Lock()
+		n := len(metrics.skipped)
+		metrics.mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if len(metrics.started) != 2 {
+		t.Errorf("Expected 2 started runs, got %v", metrics.started)
+	}
+	if err, ok := metrics.finished["ok"]; !ok || err != nil {
+		t.Errorf("Expected ok to finish without error, got %v", metrics.finished)
+	}
+	if err := metrics.finished["bad"]; !errors.Is(err, errFailed) {
+		t.Errorf("Expected bad to finish with error, got %v", err)
+	}
+	if len(metrics.skipped) != 1 || metrics.skipped[0] != "held" {
+		t.Errorf("Expected held to be skipped, got %v", metrics.skipped)
+	}
+	if !metrics.acquired["ok"] || metrics.acquired["held"] {
+		t.Errorf("Unexpected lock acquisitions: %v", metrics.acquired)
+	}
+}
+
+func TestManagerRecordsPanics(t *testing.T) {
+	metrics := &recordingMetrics{}
+	scheduler := NewScheduler().WithMetrics(metrics)
+
+	failed := make(chan struct{})
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobFailed {
+			close(failed)
+		}
+	})
+
+	if _, err := scheduler.Every(1).Hours().Name("panicky").DoContext(func(ctx context.Context) error {
+		panic("boom")
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+	select {
+	case <-failed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected panicking job to fail")
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if len(metrics.panicked) != 1 || metrics.panicked[0] != "panicky" {
+		t.Errorf("Expected panic of panicky to be recorded, got %v", metrics.panicked)
+	}
+	if err := metrics.finished["panicky"]; err == nil {
+		t.Error("Expected panicked run to be recorded as failed")
+	}
+}
+
+func TestLeaseRecordsRenewFailures(t *testing.T) {
+	metrics := &recordingMetrics{}
+	tel := newTelemetry(nil)
+	tel.setMetrics(metrics)
+
+	l := newLease("renew-test", 30*time.Millisecond, func(ctx context.Context) error {
+		return errors.New("redis down")
+	}, tel)
+	defer l.stop()
+
+	select {
+	case <-l.Lost():
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected lease to be lost")
+	}
+
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+	if len(metrics.renewFailures) == 0 || metrics.renewFailures[0] != "renew-test" {
+		t.Errorf("Expected renew failures for renew-test, got %v", metrics.renewFailures)
+	}
+}
@@ -0,0 +1,55 @@
+package scheduler
+
+import "sync"
+
+// JobMiddleware bọc việc thực thi job để xử lý các tác vụ chung (logging, metrics, tracing, ...)
+// mà không lặp lại trong từng hàm job.
+//
+// Middleware được gọi cho mỗi lần chạy, kể cả từng lần thử lại. Tên, tags và số thứ tự lần chạy
+// của job được lấy qua JobInfoFromContext(ctx). Middleware có thể sửa context trước khi gọi next,
+// bỏ qua next hoặc thay đổi lỗi trả về.
+//
+// Example:
+//
+//	manager.Use(func(next scheduler.JobFunc) scheduler.JobFunc {
+//		return func(ctx context.Context) error {
+//			info, _ := scheduler.JobInfoFromContext(ctx)
+//			start := time.Now()
+//			err := next(ctx)
+//			log.Printf("job %s (attempt %d) took %v: %v", info.Name, info.Attempt, time.Since(start), err)
+//			return err
+//		}
+//	})
+type JobMiddleware func(next JobFunc) JobFunc
+
+// middlewareChain lưu trữ các JobMiddleware áp dụng cho mọi job của Manager.
+type middlewareChain struct {
+	mu         sync.RWMutex
+	middleware []JobMiddleware
+}
+
+// use đăng ký thêm các middleware.
+func (c *middlewareChain) use(middleware ...JobMiddleware) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, mw := range middleware {
+		if mw != nil {
+			c.middleware = append(c.middleware, mw)
+		}
+	}
+}
+
+// wrap bọc jobFun bởi các middleware đã đăng ký, tiếp theo là middleware của job.
+func (c *middlewareChain) wrap(jobFun JobFunc, middleware []JobMiddleware) JobFunc {
+	c.mu.RLock()
+	all := make([]JobMiddleware, 0, len(c.middleware)+len(middleware))
+	all = append(all, c.middleware...)
+	c.mu.RUnlock()
+	all = append(all, middleware...)
+
+	// Middleware đăng ký trước là lớp ngoài cùng
+	for i := len(all) - 1; i >= 0; i-- {
+		jobFun = all[i](jobFun)
+	}
+	return jobFun
+}
@@ -0,0 +1,181 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingMiddleware trả về middleware ghi lại tên và thông tin job vào calls.
+func recordingMiddleware(mu *sync.Mutex, calls *[]string, name string) JobMiddleware {
+	return func(next JobFunc) JobFunc {
+		return func(ctx context.Context) error {
+			info, _ := JobInfoFromContext(ctx)
+			mu.Lock()
+			*calls = append(*calls, name+":"+info.Name)
+			mu.Unlock()
+			return next(ctx)
+		}
+	}
+}
+
+func TestMiddlewareChainOrder(t *testing.T) {
+	var chain middlewareChain
+	var mu sync.Mutex
+	var calls []string
+
+	chain.use(recordingMiddleware(&mu, &calls, "first"), nil, recordingMiddleware(&mu, &calls, "second"))
+	wrapped := chain.wrap(func(ctx context.Context) error {
+		calls = append(calls, "job")
+		return nil
+	}, []JobMiddleware{recordingMiddleware(&mu, &calls, "job-mw")})
+
+	ctx := context.WithValue(context.Background(), jobInfoKey{}, JobInfo{Name: "test"})
+	if err := wrapped(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"first:test", "second:test", "job-mw:test", "job"}
+	if len(calls) != len(expected) {
+		t.Fatalf("Expected calls %v, got %v", expected, calls)
+	}
+	for i := range expected {
+		if calls[i] != expected[i] {
+			t.Errorf("Expected calls %v, got %v", expected, calls)
+			break
+		}
+	}
+}
+
+func TestManagerUseAndJobMiddleware(t *testing.T) {
+	scheduler := NewScheduler()
+
+	var mu sync.Mutex
+	var calls []string
+	done := make(chan struct{}, 4)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobSucceeded || event.Type == EventJobFailed {
+			done <- struct{}{}
+		}
+	})
+
+	_, err := scheduler.Every(1).Hours().Name("plain").Do(func() {})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	_, err = scheduler.Every(1).Hours().Name("wrapped").
+		Middleware(recordingMiddleware(&mu, &calls, "job")).
+		DoContext(func(ctx context.Context) error { return nil })
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	// Middleware toàn cục áp dụng cả cho job đã được lên lịch trước đó
+	scheduler.Use(recordingMiddleware(&mu, &calls, "global"))
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Expected both jobs to run")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	got := make(map[string]bool)
+	for _, call := range calls {
+		got[call] = true
+	}
+	for _, want := range []string{"global:plain", "global:wrapped", "job:wrapped"} {
+		if !got[want] {
+			t.Errorf("Expected middleware call %q, got %v", want, calls)
+		}
+	}
+	if got["job:plain"] {
+		t.Error("Expected job middleware to apply only to its own job")
+	}
+}
+
+func TestMiddlewareRunsForEachAttempt(t *testing.T) {
+	scheduler := NewScheduler()
+
+	var mu sync.Mutex
+	var attempts []int
+	scheduler.Use(func(next JobFunc) JobFunc {
+		return func(ctx context.Context) error {
+			info, _ := JobInfoFromContext(ctx)
+			mu.Lock()
+			attempts = append(attempts, info.Attempt)
+			mu.Unlock()
+			return next(ctx)
+		}
+	})
+
+	done := make(chan struct{})
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobFailed && event.Attempt == 3 {
+			close(done)
+		}
+	})
+
+	_, err := scheduler.Every(1).Hours().Retry(RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond}).
+		DoContext(func(ctx context.Context) error { return errors.New("failed") })
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to fail three times")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(attempts) != 3 || attempts[0] != 1 || attempts[2] != 3 {
+		t.Errorf("Expected middleware to run for attempts 1..3, got %v", attempts)
+	}
+}
+
+func TestMiddlewarePanicIsRecovered(t *testing.T) {
+	scheduler := NewScheduler()
+	scheduler.Use(func(next JobFunc) JobFunc {
+		return func(ctx context.Context) error {
+			panic("middleware boom")
+		}
+	})
+
+	failed := make(chan error, 1)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobFailed {
+			failed <- event.Err
+		}
+	})
+
+	_, err := scheduler.Every(1).Hours().DoContext(func(ctx context.Context) error { return nil })
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case err := <-failed:
+		var panicErr *PanicError
+		if !errors.As(err, &panicErr) || panicErr.Value != "middleware boom" {
+			t.Errorf("Expected *PanicError from middleware, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to fail")
+	}
+}
@@ -3,12 +3,18 @@
 package scheduler_mocks
 
 import (
+	context "context"
+
 	gocron "github.com/go-co-op/gocron"
 	mock "github.com/stretchr/testify/mock"
 
 	scheduler "go.fork.vn/scheduler"
 
+	slog "log/slog"
+
 	time "time"
+
+	trace "go.opentelemetry.io/otel/trace"
 )
 
 // MockManager is an autogenerated mock type for the Manager type
@@ -316,6 +322,122 @@ func (_c *MockManager_Do_Call) RunAndReturn(run func(interface{}, ...interface{}
 	return _c
 }
 
+// DoContext provides a mock function with given fields: jobFun
+func (_m *MockManager) DoContext(jobFun scheduler.JobFunc) (*gocron.Job, error) {
+	ret := _m.Called(jobFun)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DoContext")
+	}
+
+	var r0 *gocron.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(scheduler.JobFunc) (*gocron.Job, error)); ok {
+		return rf(jobFun)
+	}
+	if rf, ok := ret.Get(0).(func(scheduler.JobFunc) *gocron.Job); ok {
+		r0 = rf(jobFun)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocron.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(scheduler.JobFunc) error); ok {
+		r1 = rf(jobFun)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_DoContext_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DoContext'
+type MockManager_DoContext_Call struct {
+	*mock.Call
+}
+
+// DoContext is a helper method to define mock.On call
+//   - jobFun scheduler.JobFunc
+func (_e *MockManager_Expecter) DoContext(jobFun interface{}) *MockManager_DoContext_Call {
+	return &MockManager_DoContext_Call{Call: _e.mock.On("DoContext", jobFun)}
+}
+
+func (_c *MockManager_DoContext_Call) Run(run func(jobFun scheduler.JobFunc)) *MockManager_DoContext_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(scheduler.JobFunc))
+	})
+	return _c
+}
+
+func (_c *MockManager_DoContext_Call) Return(_a0 *gocron.Job, _a1 error) *MockManager_DoContext_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_DoContext_Call) RunAndReturn(run func(scheduler.JobFunc) (*gocron.Job, error)) *MockManager_DoContext_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DoHandler provides a mock function with given fields: name
+func (_m *MockManager) DoHandler(name string) (*gocron.Job, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DoHandler")
+	}
+
+	var r0 *gocron.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*gocron.Job, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *gocron.Job); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocron.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_DoHandler_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DoHandler'
+type MockManager_DoHandler_Call struct {
+	*mock.Call
+}
+
+// DoHandler is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) DoHandler(name interface{}) *MockManager_DoHandler_Call {
+	return &MockManager_DoHandler_Call{Call: _e.mock.On("DoHandler", name)}
+}
+
+func (_c *MockManager_DoHandler_Call) Run(run func(name string)) *MockManager_DoHandler_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_DoHandler_Call) Return(_a0 *gocron.Job, _a1 error) *MockManager_DoHandler_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_DoHandler_Call) RunAndReturn(run func(string) (*gocron.Job, error)) *MockManager_DoHandler_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Every provides a mock function with given fields: interval
 func (_m *MockManager) Every(interval interface{}) scheduler.Manager {
 	ret := _m.Called(interval)
@@ -482,6 +604,67 @@ func (_c *MockManager_GetScheduler_Call) RunAndReturn(run func() *gocron.Schedul
 	return _c
 }
 
+// History provides a mock function with given fields: ctx, job, limit, offset
+func (_m *MockManager) History(ctx context.Context, job string, limit int, offset int) ([]scheduler.JobRun, error) {
+	ret := _m.Called(ctx, job, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []scheduler.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]scheduler.JobRun, error)); ok {
+		return rf(ctx, job, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []scheduler.JobRun); ok {
+		r0 = rf(ctx, job, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]scheduler.JobRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, job, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type MockManager_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job string
+//   - limit int
+//   - offset int
+func (_e *MockManager_Expecter) History(ctx interface{}, job interface{}, limit interface{}, offset interface{}) *MockManager_History_Call {
+	return &MockManager_History_Call{Call: _e.mock.On("History", ctx, job, limit, offset)}
+}
+
+func (_c *MockManager_History_Call) Run(run func(ctx context.Context, job string, limit int, offset int)) *MockManager_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockManager_History_Call) Return(_a0 []scheduler.JobRun, _a1 error) *MockManager_History_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_History_Call) RunAndReturn(run func(context.Context, string, int, int) ([]scheduler.JobRun, error)) *MockManager_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Hours provides a mock function with no fields
 func (_m *MockManager) Hours() scheduler.Manager {
 	ret := _m.Called()
@@ -529,6 +712,52 @@ func (_c *MockManager_Hours_Call) RunAndReturn(run func() scheduler.Manager) *Mo
 	return _c
 }
 
+// IsPaused provides a mock function with given fields: name
+func (_m *MockManager) IsPaused(name string) bool {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsPaused")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// MockManager_IsPaused_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsPaused'
+type MockManager_IsPaused_Call struct {
+	*mock.Call
+}
+
+// IsPaused is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) IsPaused(name interface{}) *MockManager_IsPaused_Call {
+	return &MockManager_IsPaused_Call{Call: _e.mock.On("IsPaused", name)}
+}
+
+func (_c *MockManager_IsPaused_Call) Run(run func(name string)) *MockManager_IsPaused_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_IsPaused_Call) Return(_a0 bool) *MockManager_IsPaused_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_IsPaused_Call) RunAndReturn(run func(string) bool) *MockManager_IsPaused_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IsRunning provides a mock function with no fields
 func (_m *MockManager) IsRunning() bool {
 	ret := _m.Called()
@@ -574,6 +803,114 @@ func (_c *MockManager_IsRunning_Call) RunAndReturn(run func() bool) *MockManager
 	return _c
 }
 
+// Jobs provides a mock function with no fields
+func (_m *MockManager) Jobs() []scheduler.JobStatus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Jobs")
+	}
+
+	var r0 []scheduler.JobStatus
+	if rf, ok := ret.Get(0).(func() []scheduler.JobStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]scheduler.JobStatus)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Jobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Jobs'
+type MockManager_Jobs_Call struct {
+	*mock.Call
+}
+
+// Jobs is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Jobs() *MockManager_Jobs_Call {
+	return &MockManager_Jobs_Call{Call: _e.mock.On("Jobs")}
+}
+
+func (_c *MockManager_Jobs_Call) Run(run func()) *MockManager_Jobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Jobs_Call) Return(_a0 []scheduler.JobStatus) *MockManager_Jobs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Jobs_Call) RunAndReturn(run func() []scheduler.JobStatus) *MockManager_Jobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Middleware provides a mock function with given fields: middleware
+func (_m *MockManager) Middleware(middleware ...scheduler.JobMiddleware) scheduler.Manager {
+	_va := make([]interface{}, len(middleware))
+	for _i := range middleware {
+		_va[_i] = middleware[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Middleware")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(...scheduler.JobMiddleware) scheduler.Manager); ok {
+		r0 = rf(middleware...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Middleware_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Middleware'
+type MockManager_Middleware_Call struct {
+	*mock.Call
+}
+
+// Middleware is a helper method to define mock.On call
+//   - middleware ...scheduler.JobMiddleware
+func (_e *MockManager_Expecter) Middleware(middleware ...interface{}) *MockManager_Middleware_Call {
+	return &MockManager_Middleware_Call{Call: _e.mock.On("Middleware",
+		append([]interface{}{}, middleware...)...)}
+}
+
+func (_c *MockManager_Middleware_Call) Run(run func(middleware ...scheduler.JobMiddleware)) *MockManager_Middleware_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]scheduler.JobMiddleware, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(scheduler.JobMiddleware)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockManager_Middleware_Call) Return(_a0 scheduler.Manager) *MockManager_Middleware_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Middleware_Call) RunAndReturn(run func(...scheduler.JobMiddleware) scheduler.Manager) *MockManager_Middleware_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Minutes provides a mock function with no fields
 func (_m *MockManager) Minutes() scheduler.Manager {
 	ret := _m.Called()
@@ -669,35 +1006,35 @@ func (_c *MockManager_Name_Call) RunAndReturn(run func(string) scheduler.Manager
 	return _c
 }
 
-// RegisterEventListeners provides a mock function with given fields: eventListeners
-func (_m *MockManager) RegisterEventListeners(eventListeners ...gocron.EventListener) {
-	_va := make([]interface{}, len(eventListeners))
-	for _i := range eventListeners {
-		_va[_i] = eventListeners[_i]
+// OnEvent provides a mock function with given fields: handlers
+func (_m *MockManager) OnEvent(handlers ...scheduler.EventHandler) {
+	_va := make([]interface{}, len(handlers))
+	for _i := range handlers {
+		_va[_i] = handlers[_i]
 	}
 	var _ca []interface{}
 	_ca = append(_ca, _va...)
 	_m.Called(_ca...)
 }
 
-// MockManager_RegisterEventListeners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterEventListeners'
-type MockManager_RegisterEventListeners_Call struct {
+// MockManager_OnEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OnEvent'
+type MockManager_OnEvent_Call struct {
 	*mock.Call
 }
 
-// RegisterEventListeners is a helper method to define mock.On call
-//   - eventListeners ...gocron.EventListener
-func (_e *MockManager_Expecter) RegisterEventListeners(eventListeners ...interface{}) *MockManager_RegisterEventListeners_Call {
-	return &MockManager_RegisterEventListeners_Call{Call: _e.mock.On("RegisterEventListeners",
-		append([]interface{}{}, eventListeners...)...)}
+// OnEvent is a helper method to define mock.On call
+//   - handlers ...scheduler.EventHandler
+func (_e *MockManager_Expecter) OnEvent(handlers ...interface{}) *MockManager_OnEvent_Call {
+	return &MockManager_OnEvent_Call{Call: _e.mock.On("OnEvent",
+		append([]interface{}{}, handlers...)...)}
 }
 
-func (_c *MockManager_RegisterEventListeners_Call) Run(run func(eventListeners ...gocron.EventListener)) *MockManager_RegisterEventListeners_Call {
+func (_c *MockManager_OnEvent_Call) Run(run func(handlers ...scheduler.EventHandler)) *MockManager_OnEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]gocron.EventListener, len(args)-0)
+		variadicArgs := make([]scheduler.EventHandler, len(args)-0)
 		for i, a := range args[0:] {
 			if a != nil {
-				variadicArgs[i] = a.(gocron.EventListener)
+				variadicArgs[i] = a.(scheduler.EventHandler)
 			}
 		}
 		run(variadicArgs...)
@@ -705,27 +1042,27 @@ func (_c *MockManager_RegisterEventListeners_Call) Run(run func(eventListeners .
 	return _c
 }
 
-func (_c *MockManager_RegisterEventListeners_Call) Return() *MockManager_RegisterEventListeners_Call {
+func (_c *MockManager_OnEvent_Call) Return() *MockManager_OnEvent_Call {
 	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockManager_RegisterEventListeners_Call) RunAndReturn(run func(...gocron.EventListener)) *MockManager_RegisterEventListeners_Call {
+func (_c *MockManager_OnEvent_Call) RunAndReturn(run func(...scheduler.EventHandler)) *MockManager_OnEvent_Call {
 	_c.Run(run)
 	return _c
 }
 
-// RemoveByTag provides a mock function with given fields: tag
-func (_m *MockManager) RemoveByTag(tag string) error {
-	ret := _m.Called(tag)
+// Pause provides a mock function with given fields: name
+func (_m *MockManager) Pause(name string) error {
+	ret := _m.Called(name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveByTag")
+		panic("no return value specified for Pause")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(tag)
+		r0 = rf(name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -733,14 +1070,301 @@ func (_m *MockManager) RemoveByTag(tag string) error {
 	return r0
 }
 
-// MockManager_RemoveByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveByTag'
-type MockManager_RemoveByTag_Call struct {
+// MockManager_Pause_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pause'
+type MockManager_Pause_Call struct {
 	*mock.Call
 }
 
-// RemoveByTag is a helper method to define mock.On call
-//   - tag string
-func (_e *MockManager_Expecter) RemoveByTag(tag interface{}) *MockManager_RemoveByTag_Call {
+// Pause is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) Pause(name interface{}) *MockManager_Pause_Call {
+	return &MockManager_Pause_Call{Call: _e.mock.On("Pause", name)}
+}
+
+func (_c *MockManager_Pause_Call) Run(run func(name string)) *MockManager_Pause_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Pause_Call) Return(_a0 error) *MockManager_Pause_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Pause_Call) RunAndReturn(run func(string) error) *MockManager_Pause_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseByTag provides a mock function with given fields: tag
+func (_m *MockManager) PauseByTag(tag string) error {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseByTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_PauseByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseByTag'
+type MockManager_PauseByTag_Call struct {
+	*mock.Call
+}
+
+// PauseByTag is a helper method to define mock.On call
+//   - tag string
+func (_e *MockManager_Expecter) PauseByTag(tag interface{}) *MockManager_PauseByTag_Call {
+	return &MockManager_PauseByTag_Call{Call: _e.mock.On("PauseByTag", tag)}
+}
+
+func (_c *MockManager_PauseByTag_Call) Run(run func(tag string)) *MockManager_PauseByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_PauseByTag_Call) Return(_a0 error) *MockManager_PauseByTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_PauseByTag_Call) RunAndReturn(run func(string) error) *MockManager_PauseByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PauseState provides a mock function with given fields: ctx
+func (_m *MockManager) PauseState(ctx context.Context) (scheduler.PauseState, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PauseState")
+	}
+
+	var r0 scheduler.PauseState
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (scheduler.PauseState, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) scheduler.PauseState); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(scheduler.PauseState)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_PauseState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PauseState'
+type MockManager_PauseState_Call struct {
+	*mock.Call
+}
+
+// PauseState is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockManager_Expecter) PauseState(ctx interface{}) *MockManager_PauseState_Call {
+	return &MockManager_PauseState_Call{Call: _e.mock.On("PauseState", ctx)}
+}
+
+func (_c *MockManager_PauseState_Call) Run(run func(ctx context.Context)) *MockManager_PauseState_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockManager_PauseState_Call) Return(_a0 scheduler.PauseState, _a1 error) *MockManager_PauseState_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_PauseState_Call) RunAndReturn(run func(context.Context) (scheduler.PauseState, error)) *MockManager_PauseState_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegisterEventListeners provides a mock function with given fields: eventListeners
+func (_m *MockManager) RegisterEventListeners(eventListeners ...gocron.EventListener) {
+	_va := make([]interface{}, len(eventListeners))
+	for _i := range eventListeners {
+		_va[_i] = eventListeners[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
+}
+
+// MockManager_RegisterEventListeners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RegisterEventListeners'
+type MockManager_RegisterEventListeners_Call struct {
+	*mock.Call
+}
+
+// RegisterEventListeners is a helper method to define mock.On call
+//   - eventListeners ...gocron.EventListener
+func (_e *MockManager_Expecter) RegisterEventListeners(eventListeners ...interface{}) *MockManager_RegisterEventListeners_Call {
+	return &MockManager_RegisterEventListeners_Call{Call: _e.mock.On("RegisterEventListeners",
+		append([]interface{}{}, eventListeners...)...)}
+}
+
+func (_c *MockManager_RegisterEventListeners_Call) Run(run func(eventListeners ...gocron.EventListener)) *MockManager_RegisterEventListeners_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]gocron.EventListener, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(gocron.EventListener)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockManager_RegisterEventListeners_Call) Return() *MockManager_RegisterEventListeners_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockManager_RegisterEventListeners_Call) RunAndReturn(run func(...gocron.EventListener)) *MockManager_RegisterEventListeners_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Registry provides a mock function with no fields
+func (_m *MockManager) Registry() *scheduler.JobRegistry {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Registry")
+	}
+
+	var r0 *scheduler.JobRegistry
+	if rf, ok := ret.Get(0).(func() *scheduler.JobRegistry); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scheduler.JobRegistry)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Registry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Registry'
+type MockManager_Registry_Call struct {
+	*mock.Call
+}
+
+// Registry is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Registry() *MockManager_Registry_Call {
+	return &MockManager_Registry_Call{Call: _e.mock.On("Registry")}
+}
+
+func (_c *MockManager_Registry_Call) Run(run func()) *MockManager_Registry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Registry_Call) Return(_a0 *scheduler.JobRegistry) *MockManager_Registry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Registry_Call) RunAndReturn(run func() *scheduler.JobRegistry) *MockManager_Registry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveByName provides a mock function with given fields: name
+func (_m *MockManager) RemoveByName(name string) error {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveByName")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_RemoveByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveByName'
+type MockManager_RemoveByName_Call struct {
+	*mock.Call
+}
+
+// RemoveByName is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) RemoveByName(name interface{}) *MockManager_RemoveByName_Call {
+	return &MockManager_RemoveByName_Call{Call: _e.mock.On("RemoveByName", name)}
+}
+
+func (_c *MockManager_RemoveByName_Call) Run(run func(name string)) *MockManager_RemoveByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_RemoveByName_Call) Return(_a0 error) *MockManager_RemoveByName_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_RemoveByName_Call) RunAndReturn(run func(string) error) *MockManager_RemoveByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveByTag provides a mock function with given fields: tag
+func (_m *MockManager) RemoveByTag(tag string) error {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveByTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_RemoveByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveByTag'
+type MockManager_RemoveByTag_Call struct {
+	*mock.Call
+}
+
+// RemoveByTag is a helper method to define mock.On call
+//   - tag string
+func (_e *MockManager_Expecter) RemoveByTag(tag interface{}) *MockManager_RemoveByTag_Call {
 	return &MockManager_RemoveByTag_Call{Call: _e.mock.On("RemoveByTag", tag)}
 }
 
@@ -810,27 +1434,826 @@ func (_c *MockManager_RemoveByTags_Call) Run(run func(tags ...string)) *MockMana
 	return _c
 }
 
-func (_c *MockManager_RemoveByTags_Call) Return(_a0 error) *MockManager_RemoveByTags_Call {
+func (_c *MockManager_RemoveByTags_Call) Return(_a0 error) *MockManager_RemoveByTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_RemoveByTags_Call) RunAndReturn(run func(...string) error) *MockManager_RemoveByTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reschedule provides a mock function with given fields: name, schedule
+func (_m *MockManager) Reschedule(name string, schedule scheduler.JobSchedule) error {
+	ret := _m.Called(name, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reschedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, scheduler.JobSchedule) error); ok {
+		r0 = rf(name, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_Reschedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reschedule'
+type MockManager_Reschedule_Call struct {
+	*mock.Call
+}
+
+// Reschedule is a helper method to define mock.On call
+//   - name string
+//   - schedule scheduler.JobSchedule
+func (_e *MockManager_Expecter) Reschedule(name interface{}, schedule interface{}) *MockManager_Reschedule_Call {
+	return &MockManager_Reschedule_Call{Call: _e.mock.On("Reschedule", name, schedule)}
+}
+
+func (_c *MockManager_Reschedule_Call) Run(run func(name string, schedule scheduler.JobSchedule)) *MockManager_Reschedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(scheduler.JobSchedule))
+	})
+	return _c
+}
+
+func (_c *MockManager_Reschedule_Call) Return(_a0 error) *MockManager_Reschedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Reschedule_Call) RunAndReturn(run func(string, scheduler.JobSchedule) error) *MockManager_Reschedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resume provides a mock function with given fields: name
+func (_m *MockManager) Resume(name string) error {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resume")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_Resume_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resume'
+type MockManager_Resume_Call struct {
+	*mock.Call
+}
+
+// Resume is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) Resume(name interface{}) *MockManager_Resume_Call {
+	return &MockManager_Resume_Call{Call: _e.mock.On("Resume", name)}
+}
+
+func (_c *MockManager_Resume_Call) Run(run func(name string)) *MockManager_Resume_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_Resume_Call) Return(_a0 error) *MockManager_Resume_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Resume_Call) RunAndReturn(run func(string) error) *MockManager_Resume_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResumeByTag provides a mock function with given fields: tag
+func (_m *MockManager) ResumeByTag(tag string) error {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResumeByTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_ResumeByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResumeByTag'
+type MockManager_ResumeByTag_Call struct {
+	*mock.Call
+}
+
+// ResumeByTag is a helper method to define mock.On call
+//   - tag string
+func (_e *MockManager_Expecter) ResumeByTag(tag interface{}) *MockManager_ResumeByTag_Call {
+	return &MockManager_ResumeByTag_Call{Call: _e.mock.On("ResumeByTag", tag)}
+}
+
+func (_c *MockManager_ResumeByTag_Call) Run(run func(tag string)) *MockManager_ResumeByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_ResumeByTag_Call) Return(_a0 error) *MockManager_ResumeByTag_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_ResumeByTag_Call) RunAndReturn(run func(string) error) *MockManager_ResumeByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Retry provides a mock function with given fields: policy
+func (_m *MockManager) Retry(policy scheduler.RetryPolicy) scheduler.Manager {
+	ret := _m.Called(policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Retry")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(scheduler.RetryPolicy) scheduler.Manager); ok {
+		r0 = rf(policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Retry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Retry'
+type MockManager_Retry_Call struct {
+	*mock.Call
+}
+
+// Retry is a helper method to define mock.On call
+//   - policy scheduler.RetryPolicy
+func (_e *MockManager_Expecter) Retry(policy interface{}) *MockManager_Retry_Call {
+	return &MockManager_Retry_Call{Call: _e.mock.On("Retry", policy)}
+}
+
+func (_c *MockManager_Retry_Call) Run(run func(policy scheduler.RetryPolicy)) *MockManager_Retry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(scheduler.RetryPolicy))
+	})
+	return _c
+}
+
+func (_c *MockManager_Retry_Call) Return(_a0 scheduler.Manager) *MockManager_Retry_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Retry_Call) RunAndReturn(run func(scheduler.RetryPolicy) scheduler.Manager) *MockManager_Retry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunByTag provides a mock function with given fields: tag
+func (_m *MockManager) RunByTag(tag string) (*scheduler.RunHandle, error) {
+	ret := _m.Called(tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunByTag")
+	}
+
+	var r0 *scheduler.RunHandle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*scheduler.RunHandle, error)); ok {
+		return rf(tag)
+	}
+	if rf, ok := ret.Get(0).(func(string) *scheduler.RunHandle); ok {
+		r0 = rf(tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scheduler.RunHandle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_RunByTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunByTag'
+type MockManager_RunByTag_Call struct {
+	*mock.Call
+}
+
+// RunByTag is a helper method to define mock.On call
+//   - tag string
+func (_e *MockManager_Expecter) RunByTag(tag interface{}) *MockManager_RunByTag_Call {
+	return &MockManager_RunByTag_Call{Call: _e.mock.On("RunByTag", tag)}
+}
+
+func (_c *MockManager_RunByTag_Call) Run(run func(tag string)) *MockManager_RunByTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_RunByTag_Call) Return(_a0 *scheduler.RunHandle, _a1 error) *MockManager_RunByTag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_RunByTag_Call) RunAndReturn(run func(string) (*scheduler.RunHandle, error)) *MockManager_RunByTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunNow provides a mock function with given fields: name
+func (_m *MockManager) RunNow(name string) (*scheduler.RunHandle, error) {
+	ret := _m.Called(name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunNow")
+	}
+
+	var r0 *scheduler.RunHandle
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*scheduler.RunHandle, error)); ok {
+		return rf(name)
+	}
+	if rf, ok := ret.Get(0).(func(string) *scheduler.RunHandle); ok {
+		r0 = rf(name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*scheduler.RunHandle)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_RunNow_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunNow'
+type MockManager_RunNow_Call struct {
+	*mock.Call
+}
+
+// RunNow is a helper method to define mock.On call
+//   - name string
+func (_e *MockManager_Expecter) RunNow(name interface{}) *MockManager_RunNow_Call {
+	return &MockManager_RunNow_Call{Call: _e.mock.On("RunNow", name)}
+}
+
+func (_c *MockManager_RunNow_Call) Run(run func(name string)) *MockManager_RunNow_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockManager_RunNow_Call) Return(_a0 *scheduler.RunHandle, _a1 error) *MockManager_RunNow_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_RunNow_Call) RunAndReturn(run func(string) (*scheduler.RunHandle, error)) *MockManager_RunNow_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleJob provides a mock function with given fields: job
+func (_m *MockManager) ScheduleJob(job scheduler.JobConfig) (*gocron.Job, error) {
+	ret := _m.Called(job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleJob")
+	}
+
+	var r0 *gocron.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(scheduler.JobConfig) (*gocron.Job, error)); ok {
+		return rf(job)
+	}
+	if rf, ok := ret.Get(0).(func(scheduler.JobConfig) *gocron.Job); ok {
+		r0 = rf(job)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gocron.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(scheduler.JobConfig) error); ok {
+		r1 = rf(job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockManager_ScheduleJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleJob'
+type MockManager_ScheduleJob_Call struct {
+	*mock.Call
+}
+
+// ScheduleJob is a helper method to define mock.On call
+//   - job scheduler.JobConfig
+func (_e *MockManager_Expecter) ScheduleJob(job interface{}) *MockManager_ScheduleJob_Call {
+	return &MockManager_ScheduleJob_Call{Call: _e.mock.On("ScheduleJob", job)}
+}
+
+func (_c *MockManager_ScheduleJob_Call) Run(run func(job scheduler.JobConfig)) *MockManager_ScheduleJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(scheduler.JobConfig))
+	})
+	return _c
+}
+
+func (_c *MockManager_ScheduleJob_Call) Return(_a0 *gocron.Job, _a1 error) *MockManager_ScheduleJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockManager_ScheduleJob_Call) RunAndReturn(run func(scheduler.JobConfig) (*gocron.Job, error)) *MockManager_ScheduleJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Second provides a mock function with no fields
+func (_m *MockManager) Second() scheduler.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Second")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func() scheduler.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Second_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Second'
+type MockManager_Second_Call struct {
+	*mock.Call
+}
+
+// Second is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Second() *MockManager_Second_Call {
+	return &MockManager_Second_Call{Call: _e.mock.On("Second")}
+}
+
+func (_c *MockManager_Second_Call) Run(run func()) *MockManager_Second_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Second_Call) Return(_a0 scheduler.Manager) *MockManager_Second_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Second_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_Second_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Seconds provides a mock function with no fields
+func (_m *MockManager) Seconds() scheduler.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Seconds")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func() scheduler.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Seconds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Seconds'
+type MockManager_Seconds_Call struct {
+	*mock.Call
+}
+
+// Seconds is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Seconds() *MockManager_Seconds_Call {
+	return &MockManager_Seconds_Call{Call: _e.mock.On("Seconds")}
+}
+
+func (_c *MockManager_Seconds_Call) Run(run func()) *MockManager_Seconds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Seconds_Call) Return(_a0 scheduler.Manager) *MockManager_Seconds_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Seconds_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_Seconds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Shutdown provides a mock function with given fields: ctx
+func (_m *MockManager) Shutdown(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Shutdown")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_Shutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Shutdown'
+type MockManager_Shutdown_Call struct {
+	*mock.Call
+}
+
+// Shutdown is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockManager_Expecter) Shutdown(ctx interface{}) *MockManager_Shutdown_Call {
+	return &MockManager_Shutdown_Call{Call: _e.mock.On("Shutdown", ctx)}
+}
+
+func (_c *MockManager_Shutdown_Call) Run(run func(ctx context.Context)) *MockManager_Shutdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockManager_Shutdown_Call) Return(_a0 error) *MockManager_Shutdown_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_Shutdown_Call) RunAndReturn(run func(context.Context) error) *MockManager_Shutdown_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SingletonMode provides a mock function with no fields
+func (_m *MockManager) SingletonMode() scheduler.Manager {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SingletonMode")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func() scheduler.Manager); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_SingletonMode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SingletonMode'
+type MockManager_SingletonMode_Call struct {
+	*mock.Call
+}
+
+// SingletonMode is a helper method to define mock.On call
+func (_e *MockManager_Expecter) SingletonMode() *MockManager_SingletonMode_Call {
+	return &MockManager_SingletonMode_Call{Call: _e.mock.On("SingletonMode")}
+}
+
+func (_c *MockManager_SingletonMode_Call) Run(run func()) *MockManager_SingletonMode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_SingletonMode_Call) Return(_a0 scheduler.Manager) *MockManager_SingletonMode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_SingletonMode_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_SingletonMode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartAsync provides a mock function with no fields
+func (_m *MockManager) StartAsync() {
+	_m.Called()
+}
+
+// MockManager_StartAsync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartAsync'
+type MockManager_StartAsync_Call struct {
+	*mock.Call
+}
+
+// StartAsync is a helper method to define mock.On call
+func (_e *MockManager_Expecter) StartAsync() *MockManager_StartAsync_Call {
+	return &MockManager_StartAsync_Call{Call: _e.mock.On("StartAsync")}
+}
+
+func (_c *MockManager_StartAsync_Call) Run(run func()) *MockManager_StartAsync_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_StartAsync_Call) Return() *MockManager_StartAsync_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockManager_StartAsync_Call) RunAndReturn(run func()) *MockManager_StartAsync_Call {
+	_c.Run(run)
+	return _c
+}
+
+// StartAt provides a mock function with given fields: _a0
+func (_m *MockManager) StartAt(_a0 time.Time) scheduler.Manager {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartAt")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(time.Time) scheduler.Manager); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_StartAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartAt'
+type MockManager_StartAt_Call struct {
+	*mock.Call
+}
+
+// StartAt is a helper method to define mock.On call
+//   - _a0 time.Time
+func (_e *MockManager_Expecter) StartAt(_a0 interface{}) *MockManager_StartAt_Call {
+	return &MockManager_StartAt_Call{Call: _e.mock.On("StartAt", _a0)}
+}
+
+func (_c *MockManager_StartAt_Call) Run(run func(_a0 time.Time)) *MockManager_StartAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockManager_StartAt_Call) Return(_a0 scheduler.Manager) *MockManager_StartAt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_StartAt_Call) RunAndReturn(run func(time.Time) scheduler.Manager) *MockManager_StartAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StartBlocking provides a mock function with no fields
+func (_m *MockManager) StartBlocking() {
+	_m.Called()
+}
+
+// MockManager_StartBlocking_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartBlocking'
+type MockManager_StartBlocking_Call struct {
+	*mock.Call
+}
+
+// StartBlocking is a helper method to define mock.On call
+func (_e *MockManager_Expecter) StartBlocking() *MockManager_StartBlocking_Call {
+	return &MockManager_StartBlocking_Call{Call: _e.mock.On("StartBlocking")}
+}
+
+func (_c *MockManager_StartBlocking_Call) Run(run func()) *MockManager_StartBlocking_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_StartBlocking_Call) Return() *MockManager_StartBlocking_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockManager_StartBlocking_Call) RunAndReturn(run func()) *MockManager_StartBlocking_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Stop provides a mock function with no fields
+func (_m *MockManager) Stop() {
+	_m.Called()
+}
+
+// MockManager_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
+type MockManager_Stop_Call struct {
+	*mock.Call
+}
+
+// Stop is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Stop() *MockManager_Stop_Call {
+	return &MockManager_Stop_Call{Call: _e.mock.On("Stop")}
+}
+
+func (_c *MockManager_Stop_Call) Run(run func()) *MockManager_Stop_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockManager_Stop_Call) Return() *MockManager_Stop_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockManager_Stop_Call) RunAndReturn(run func()) *MockManager_Stop_Call {
+	_c.Run(run)
+	return _c
+}
+
+// SyncJobs provides a mock function with given fields: jobs
+func (_m *MockManager) SyncJobs(jobs []scheduler.JobConfig) error {
+	ret := _m.Called(jobs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncJobs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]scheduler.JobConfig) error); ok {
+		r0 = rf(jobs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockManager_SyncJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SyncJobs'
+type MockManager_SyncJobs_Call struct {
+	*mock.Call
+}
+
+// SyncJobs is a helper method to define mock.On call
+//   - jobs []scheduler.JobConfig
+func (_e *MockManager_Expecter) SyncJobs(jobs interface{}) *MockManager_SyncJobs_Call {
+	return &MockManager_SyncJobs_Call{Call: _e.mock.On("SyncJobs", jobs)}
+}
+
+func (_c *MockManager_SyncJobs_Call) Run(run func(jobs []scheduler.JobConfig)) *MockManager_SyncJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]scheduler.JobConfig))
+	})
+	return _c
+}
+
+func (_c *MockManager_SyncJobs_Call) Return(_a0 error) *MockManager_SyncJobs_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockManager_SyncJobs_Call) RunAndReturn(run func([]scheduler.JobConfig) error) *MockManager_SyncJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Tag provides a mock function with given fields: tags
+func (_m *MockManager) Tag(tags ...string) scheduler.Manager {
+	_va := make([]interface{}, len(tags))
+	for _i := range tags {
+		_va[_i] = tags[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Tag")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(...string) scheduler.Manager); ok {
+		r0 = rf(tags...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
+}
+
+// MockManager_Tag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tag'
+type MockManager_Tag_Call struct {
+	*mock.Call
+}
+
+// Tag is a helper method to define mock.On call
+//   - tags ...string
+func (_e *MockManager_Expecter) Tag(tags ...interface{}) *MockManager_Tag_Call {
+	return &MockManager_Tag_Call{Call: _e.mock.On("Tag",
+		append([]interface{}{}, tags...)...)}
+}
+
+func (_c *MockManager_Tag_Call) Run(run func(tags ...string)) *MockManager_Tag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockManager_Tag_Call) Return(_a0 scheduler.Manager) *MockManager_Tag_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_RemoveByTags_Call) RunAndReturn(run func(...string) error) *MockManager_RemoveByTags_Call {
+func (_c *MockManager_Tag_Call) RunAndReturn(run func(...string) scheduler.Manager) *MockManager_Tag_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Second provides a mock function with no fields
-func (_m *MockManager) Second() scheduler.Manager {
-	ret := _m.Called()
+// Timeout provides a mock function with given fields: d
+func (_m *MockManager) Timeout(d time.Duration) scheduler.Manager {
+	ret := _m.Called(d)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Second")
+		panic("no return value specified for Timeout")
 	}
 
 	var r0 scheduler.Manager
-	if rf, ok := ret.Get(0).(func() scheduler.Manager); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(time.Duration) scheduler.Manager); ok {
+		r0 = rf(d)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(scheduler.Manager)
@@ -840,86 +2263,86 @@ func (_m *MockManager) Second() scheduler.Manager {
 	return r0
 }
 
-// MockManager_Second_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Second'
-type MockManager_Second_Call struct {
+// MockManager_Timeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Timeout'
+type MockManager_Timeout_Call struct {
 	*mock.Call
 }
 
-// Second is a helper method to define mock.On call
-func (_e *MockManager_Expecter) Second() *MockManager_Second_Call {
-	return &MockManager_Second_Call{Call: _e.mock.On("Second")}
+// Timeout is a helper method to define mock.On call
+//   - d time.Duration
+func (_e *MockManager_Expecter) Timeout(d interface{}) *MockManager_Timeout_Call {
+	return &MockManager_Timeout_Call{Call: _e.mock.On("Timeout", d)}
 }
 
-func (_c *MockManager_Second_Call) Run(run func()) *MockManager_Second_Call {
+func (_c *MockManager_Timeout_Call) Run(run func(d time.Duration)) *MockManager_Timeout_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(time.Duration))
 	})
 	return _c
 }
 
-func (_c *MockManager_Second_Call) Return(_a0 scheduler.Manager) *MockManager_Second_Call {
+func (_c *MockManager_Timeout_Call) Return(_a0 scheduler.Manager) *MockManager_Timeout_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_Second_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_Second_Call {
+func (_c *MockManager_Timeout_Call) RunAndReturn(run func(time.Duration) scheduler.Manager) *MockManager_Timeout_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Seconds provides a mock function with no fields
-func (_m *MockManager) Seconds() scheduler.Manager {
-	ret := _m.Called()
-
-	if len(ret) == 0 {
-		panic("no return value specified for Seconds")
-	}
-
-	var r0 scheduler.Manager
-	if rf, ok := ret.Get(0).(func() scheduler.Manager); ok {
-		r0 = rf()
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(scheduler.Manager)
-		}
+// Use provides a mock function with given fields: middleware
+func (_m *MockManager) Use(middleware ...scheduler.JobMiddleware) {
+	_va := make([]interface{}, len(middleware))
+	for _i := range middleware {
+		_va[_i] = middleware[_i]
 	}
-
-	return r0
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	_m.Called(_ca...)
 }
 
-// MockManager_Seconds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Seconds'
-type MockManager_Seconds_Call struct {
+// MockManager_Use_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Use'
+type MockManager_Use_Call struct {
 	*mock.Call
 }
 
-// Seconds is a helper method to define mock.On call
-func (_e *MockManager_Expecter) Seconds() *MockManager_Seconds_Call {
-	return &MockManager_Seconds_Call{Call: _e.mock.On("Seconds")}
+// Use is a helper method to define mock.On call
+//   - middleware ...scheduler.JobMiddleware
+func (_e *MockManager_Expecter) Use(middleware ...interface{}) *MockManager_Use_Call {
+	return &MockManager_Use_Call{Call: _e.mock.On("Use",
+		append([]interface{}{}, middleware...)...)}
 }
 
-func (_c *MockManager_Seconds_Call) Run(run func()) *MockManager_Seconds_Call {
+func (_c *MockManager_Use_Call) Run(run func(middleware ...scheduler.JobMiddleware)) *MockManager_Use_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		variadicArgs := make([]scheduler.JobMiddleware, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(scheduler.JobMiddleware)
+			}
+		}
+		run(variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockManager_Seconds_Call) Return(_a0 scheduler.Manager) *MockManager_Seconds_Call {
-	_c.Call.Return(_a0)
+func (_c *MockManager_Use_Call) Return() *MockManager_Use_Call {
+	_c.Call.Return()
 	return _c
 }
 
-func (_c *MockManager_Seconds_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_Seconds_Call {
-	_c.Call.Return(run)
+func (_c *MockManager_Use_Call) RunAndReturn(run func(...scheduler.JobMiddleware)) *MockManager_Use_Call {
+	_c.Run(run)
 	return _c
 }
 
-// SingletonMode provides a mock function with no fields
-func (_m *MockManager) SingletonMode() scheduler.Manager {
+// Weeks provides a mock function with no fields
+func (_m *MockManager) Weeks() scheduler.Manager {
 	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for SingletonMode")
+		panic("no return value specified for Weeks")
 	}
 
 	var r0 scheduler.Manager
@@ -934,76 +2357,92 @@ func (_m *MockManager) SingletonMode() scheduler.Manager {
 	return r0
 }
 
-// MockManager_SingletonMode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SingletonMode'
-type MockManager_SingletonMode_Call struct {
+// MockManager_Weeks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Weeks'
+type MockManager_Weeks_Call struct {
 	*mock.Call
 }
 
-// SingletonMode is a helper method to define mock.On call
-func (_e *MockManager_Expecter) SingletonMode() *MockManager_SingletonMode_Call {
-	return &MockManager_SingletonMode_Call{Call: _e.mock.On("SingletonMode")}
+// Weeks is a helper method to define mock.On call
+func (_e *MockManager_Expecter) Weeks() *MockManager_Weeks_Call {
+	return &MockManager_Weeks_Call{Call: _e.mock.On("Weeks")}
 }
 
-func (_c *MockManager_SingletonMode_Call) Run(run func()) *MockManager_SingletonMode_Call {
+func (_c *MockManager_Weeks_Call) Run(run func()) *MockManager_Weeks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run()
 	})
 	return _c
 }
 
-func (_c *MockManager_SingletonMode_Call) Return(_a0 scheduler.Manager) *MockManager_SingletonMode_Call {
+func (_c *MockManager_Weeks_Call) Return(_a0 scheduler.Manager) *MockManager_Weeks_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_SingletonMode_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_SingletonMode_Call {
+func (_c *MockManager_Weeks_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_Weeks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StartAsync provides a mock function with no fields
-func (_m *MockManager) StartAsync() {
-	_m.Called()
+// WithDistributedLocker provides a mock function with given fields: locker
+func (_m *MockManager) WithDistributedLocker(locker gocron.Locker) scheduler.Manager {
+	ret := _m.Called(locker)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithDistributedLocker")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(gocron.Locker) scheduler.Manager); ok {
+		r0 = rf(locker)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
 }
 
-// MockManager_StartAsync_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartAsync'
-type MockManager_StartAsync_Call struct {
+// MockManager_WithDistributedLocker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithDistributedLocker'
+type MockManager_WithDistributedLocker_Call struct {
 	*mock.Call
 }
 
-// StartAsync is a helper method to define mock.On call
-func (_e *MockManager_Expecter) StartAsync() *MockManager_StartAsync_Call {
-	return &MockManager_StartAsync_Call{Call: _e.mock.On("StartAsync")}
+// WithDistributedLocker is a helper method to define mock.On call
+//   - locker gocron.Locker
+func (_e *MockManager_Expecter) WithDistributedLocker(locker interface{}) *MockManager_WithDistributedLocker_Call {
+	return &MockManager_WithDistributedLocker_Call{Call: _e.mock.On("WithDistributedLocker", locker)}
 }
 
-func (_c *MockManager_StartAsync_Call) Run(run func()) *MockManager_StartAsync_Call {
+func (_c *MockManager_WithDistributedLocker_Call) Run(run func(locker gocron.Locker)) *MockManager_WithDistributedLocker_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(gocron.Locker))
 	})
 	return _c
 }
 
-func (_c *MockManager_StartAsync_Call) Return() *MockManager_StartAsync_Call {
-	_c.Call.Return()
+func (_c *MockManager_WithDistributedLocker_Call) Return(_a0 scheduler.Manager) *MockManager_WithDistributedLocker_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_StartAsync_Call) RunAndReturn(run func()) *MockManager_StartAsync_Call {
-	_c.Run(run)
+func (_c *MockManager_WithDistributedLocker_Call) RunAndReturn(run func(gocron.Locker) scheduler.Manager) *MockManager_WithDistributedLocker_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// StartAt provides a mock function with given fields: _a0
-func (_m *MockManager) StartAt(_a0 time.Time) scheduler.Manager {
-	ret := _m.Called(_a0)
+// WithHistory provides a mock function with given fields: store
+func (_m *MockManager) WithHistory(store scheduler.HistoryStore) scheduler.Manager {
+	ret := _m.Called(store)
 
 	if len(ret) == 0 {
-		panic("no return value specified for StartAt")
+		panic("no return value specified for WithHistory")
 	}
 
 	var r0 scheduler.Manager
-	if rf, ok := ret.Get(0).(func(time.Time) scheduler.Manager); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(scheduler.HistoryStore) scheduler.Manager); ok {
+		r0 = rf(store)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(scheduler.Manager)
@@ -1013,115 +2452,141 @@ func (_m *MockManager) StartAt(_a0 time.Time) scheduler.Manager {
 	return r0
 }
 
-// MockManager_StartAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartAt'
-type MockManager_StartAt_Call struct {
+// MockManager_WithHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithHistory'
+type MockManager_WithHistory_Call struct {
 	*mock.Call
 }
 
-// StartAt is a helper method to define mock.On call
-//   - _a0 time.Time
-func (_e *MockManager_Expecter) StartAt(_a0 interface{}) *MockManager_StartAt_Call {
-	return &MockManager_StartAt_Call{Call: _e.mock.On("StartAt", _a0)}
+// WithHistory is a helper method to define mock.On call
+//   - store scheduler.HistoryStore
+func (_e *MockManager_Expecter) WithHistory(store interface{}) *MockManager_WithHistory_Call {
+	return &MockManager_WithHistory_Call{Call: _e.mock.On("WithHistory", store)}
 }
 
-func (_c *MockManager_StartAt_Call) Run(run func(_a0 time.Time)) *MockManager_StartAt_Call {
+func (_c *MockManager_WithHistory_Call) Run(run func(store scheduler.HistoryStore)) *MockManager_WithHistory_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(time.Time))
+		run(args[0].(scheduler.HistoryStore))
 	})
 	return _c
 }
 
-func (_c *MockManager_StartAt_Call) Return(_a0 scheduler.Manager) *MockManager_StartAt_Call {
+func (_c *MockManager_WithHistory_Call) Return(_a0 scheduler.Manager) *MockManager_WithHistory_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_StartAt_Call) RunAndReturn(run func(time.Time) scheduler.Manager) *MockManager_StartAt_Call {
+func (_c *MockManager_WithHistory_Call) RunAndReturn(run func(scheduler.HistoryStore) scheduler.Manager) *MockManager_WithHistory_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// StartBlocking provides a mock function with no fields
-func (_m *MockManager) StartBlocking() {
-	_m.Called()
+// WithLeaderElector provides a mock function with given fields: elector
+func (_m *MockManager) WithLeaderElector(elector scheduler.LeaderElector) scheduler.Manager {
+	ret := _m.Called(elector)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithLeaderElector")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(scheduler.LeaderElector) scheduler.Manager); ok {
+		r0 = rf(elector)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
 }
 
-// MockManager_StartBlocking_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartBlocking'
-type MockManager_StartBlocking_Call struct {
+// MockManager_WithLeaderElector_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithLeaderElector'
+type MockManager_WithLeaderElector_Call struct {
 	*mock.Call
 }
 
-// StartBlocking is a helper method to define mock.On call
-func (_e *MockManager_Expecter) StartBlocking() *MockManager_StartBlocking_Call {
-	return &MockManager_StartBlocking_Call{Call: _e.mock.On("StartBlocking")}
+// WithLeaderElector is a helper method to define mock.On call
+//   - elector scheduler.LeaderElector
+func (_e *MockManager_Expecter) WithLeaderElector(elector interface{}) *MockManager_WithLeaderElector_Call {
+	return &MockManager_WithLeaderElector_Call{Call: _e.mock.On("WithLeaderElector", elector)}
 }
 
-func (_c *MockManager_StartBlocking_Call) Run(run func()) *MockManager_StartBlocking_Call {
+func (_c *MockManager_WithLeaderElector_Call) Run(run func(elector scheduler.LeaderElector)) *MockManager_WithLeaderElector_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(scheduler.LeaderElector))
 	})
 	return _c
 }
 
-func (_c *MockManager_StartBlocking_Call) Return() *MockManager_StartBlocking_Call {
-	_c.Call.Return()
+func (_c *MockManager_WithLeaderElector_Call) Return(_a0 scheduler.Manager) *MockManager_WithLeaderElector_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_StartBlocking_Call) RunAndReturn(run func()) *MockManager_StartBlocking_Call {
-	_c.Run(run)
+func (_c *MockManager_WithLeaderElector_Call) RunAndReturn(run func(scheduler.LeaderElector) scheduler.Manager) *MockManager_WithLeaderElector_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// Stop provides a mock function with no fields
-func (_m *MockManager) Stop() {
-	_m.Called()
+// WithLogger provides a mock function with given fields: logger
+func (_m *MockManager) WithLogger(logger *slog.Logger) scheduler.Manager {
+	ret := _m.Called(logger)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WithLogger")
+	}
+
+	var r0 scheduler.Manager
+	if rf, ok := ret.Get(0).(func(*slog.Logger) scheduler.Manager); ok {
+		r0 = rf(logger)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scheduler.Manager)
+		}
+	}
+
+	return r0
 }
 
-// MockManager_Stop_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stop'
-type MockManager_Stop_Call struct {
+// MockManager_WithLogger_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithLogger'
+type MockManager_WithLogger_Call struct {
 	*mock.Call
 }
 
-// Stop is a helper method to define mock.On call
-func (_e *MockManager_Expecter) Stop() *MockManager_Stop_Call {
-	return &MockManager_Stop_Call{Call: _e.mock.On("Stop")}
+// WithLogger is a helper method to define mock.On call
+//   - logger *slog.Logger
+func (_e *MockManager_Expecter) WithLogger(logger interface{}) *MockManager_WithLogger_Call {
+	return &MockManager_WithLogger_Call{Call: _e.mock.On("WithLogger", logger)}
 }
 
-func (_c *MockManager_Stop_Call) Run(run func()) *MockManager_Stop_Call {
+func (_c *MockManager_WithLogger_Call) Run(run func(logger *slog.Logger)) *MockManager_WithLogger_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(*slog.Logger))
 	})
 	return _c
 }
 
-func (_c *MockManager_Stop_Call) Return() *MockManager_Stop_Call {
-	_c.Call.Return()
+func (_c *MockManager_WithLogger_Call) Return(_a0 scheduler.Manager) *MockManager_WithLogger_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_Stop_Call) RunAndReturn(run func()) *MockManager_Stop_Call {
-	_c.Run(run)
+func (_c *MockManager_WithLogger_Call) RunAndReturn(run func(*slog.Logger) scheduler.Manager) *MockManager_WithLogger_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// Tag provides a mock function with given fields: tags
-func (_m *MockManager) Tag(tags ...string) scheduler.Manager {
-	_va := make([]interface{}, len(tags))
-	for _i := range tags {
-		_va[_i] = tags[_i]
-	}
-	var _ca []interface{}
-	_ca = append(_ca, _va...)
-	ret := _m.Called(_ca...)
+// WithMetrics provides a mock function with given fields: collector
+func (_m *MockManager) WithMetrics(collector scheduler.MetricsCollector) scheduler.Manager {
+	ret := _m.Called(collector)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Tag")
+		panic("no return value specified for WithMetrics")
 	}
 
 	var r0 scheduler.Manager
-	if rf, ok := ret.Get(0).(func(...string) scheduler.Manager); ok {
-		r0 = rf(tags...)
+	if rf, ok := ret.Get(0).(func(scheduler.MetricsCollector) scheduler.Manager); ok {
+		r0 = rf(collector)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(scheduler.Manager)
@@ -1131,52 +2596,45 @@ func (_m *MockManager) Tag(tags ...string) scheduler.Manager {
 	return r0
 }
 
-// MockManager_Tag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Tag'
-type MockManager_Tag_Call struct {
+// MockManager_WithMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithMetrics'
+type MockManager_WithMetrics_Call struct {
 	*mock.Call
 }
 
-// Tag is a helper method to define mock.On call
-//   - tags ...string
-func (_e *MockManager_Expecter) Tag(tags ...interface{}) *MockManager_Tag_Call {
-	return &MockManager_Tag_Call{Call: _e.mock.On("Tag",
-		append([]interface{}{}, tags...)...)}
+// WithMetrics is a helper method to define mock.On call
+//   - collector scheduler.MetricsCollector
+func (_e *MockManager_Expecter) WithMetrics(collector interface{}) *MockManager_WithMetrics_Call {
+	return &MockManager_WithMetrics_Call{Call: _e.mock.On("WithMetrics", collector)}
 }
 
-func (_c *MockManager_Tag_Call) Run(run func(tags ...string)) *MockManager_Tag_Call {
+func (_c *MockManager_WithMetrics_Call) Run(run func(collector scheduler.MetricsCollector)) *MockManager_WithMetrics_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]string, len(args)-0)
-		for i, a := range args[0:] {
-			if a != nil {
-				variadicArgs[i] = a.(string)
-			}
-		}
-		run(variadicArgs...)
+		run(args[0].(scheduler.MetricsCollector))
 	})
 	return _c
 }
 
-func (_c *MockManager_Tag_Call) Return(_a0 scheduler.Manager) *MockManager_Tag_Call {
+func (_c *MockManager_WithMetrics_Call) Return(_a0 scheduler.Manager) *MockManager_WithMetrics_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_Tag_Call) RunAndReturn(run func(...string) scheduler.Manager) *MockManager_Tag_Call {
+func (_c *MockManager_WithMetrics_Call) RunAndReturn(run func(scheduler.MetricsCollector) scheduler.Manager) *MockManager_WithMetrics_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Weeks provides a mock function with no fields
-func (_m *MockManager) Weeks() scheduler.Manager {
-	ret := _m.Called()
+// WithPauseStore provides a mock function with given fields: store
+func (_m *MockManager) WithPauseStore(store scheduler.PauseStore) scheduler.Manager {
+	ret := _m.Called(store)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Weeks")
+		panic("no return value specified for WithPauseStore")
 	}
 
 	var r0 scheduler.Manager
-	if rf, ok := ret.Get(0).(func() scheduler.Manager); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(scheduler.PauseStore) scheduler.Manager); ok {
+		r0 = rf(store)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(scheduler.Manager)
@@ -1186,44 +2644,45 @@ func (_m *MockManager) Weeks() scheduler.Manager {
 	return r0
 }
 
-// MockManager_Weeks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Weeks'
-type MockManager_Weeks_Call struct {
+// MockManager_WithPauseStore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithPauseStore'
+type MockManager_WithPauseStore_Call struct {
 	*mock.Call
 }
 
-// Weeks is a helper method to define mock.On call
-func (_e *MockManager_Expecter) Weeks() *MockManager_Weeks_Call {
-	return &MockManager_Weeks_Call{Call: _e.mock.On("Weeks")}
+// WithPauseStore is a helper method to define mock.On call
+//   - store scheduler.PauseStore
+func (_e *MockManager_Expecter) WithPauseStore(store interface{}) *MockManager_WithPauseStore_Call {
+	return &MockManager_WithPauseStore_Call{Call: _e.mock.On("WithPauseStore", store)}
 }
 
-func (_c *MockManager_Weeks_Call) Run(run func()) *MockManager_Weeks_Call {
+func (_c *MockManager_WithPauseStore_Call) Run(run func(store scheduler.PauseStore)) *MockManager_WithPauseStore_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(scheduler.PauseStore))
 	})
 	return _c
 }
 
-func (_c *MockManager_Weeks_Call) Return(_a0 scheduler.Manager) *MockManager_Weeks_Call {
+func (_c *MockManager_WithPauseStore_Call) Return(_a0 scheduler.Manager) *MockManager_WithPauseStore_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_Weeks_Call) RunAndReturn(run func() scheduler.Manager) *MockManager_Weeks_Call {
+func (_c *MockManager_WithPauseStore_Call) RunAndReturn(run func(scheduler.PauseStore) scheduler.Manager) *MockManager_WithPauseStore_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// WithDistributedLocker provides a mock function with given fields: locker
-func (_m *MockManager) WithDistributedLocker(locker gocron.Locker) scheduler.Manager {
-	ret := _m.Called(locker)
+// WithTracerProvider provides a mock function with given fields: provider
+func (_m *MockManager) WithTracerProvider(provider trace.TracerProvider) scheduler.Manager {
+	ret := _m.Called(provider)
 
 	if len(ret) == 0 {
-		panic("no return value specified for WithDistributedLocker")
+		panic("no return value specified for WithTracerProvider")
 	}
 
 	var r0 scheduler.Manager
-	if rf, ok := ret.Get(0).(func(gocron.Locker) scheduler.Manager); ok {
-		r0 = rf(locker)
+	if rf, ok := ret.Get(0).(func(trace.TracerProvider) scheduler.Manager); ok {
+		r0 = rf(provider)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(scheduler.Manager)
@@ -1233,30 +2692,30 @@ func (_m *MockManager) WithDistributedLocker(locker gocron.Locker) scheduler.Man
 	return r0
 }
 
-// MockManager_WithDistributedLocker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithDistributedLocker'
-type MockManager_WithDistributedLocker_Call struct {
+// MockManager_WithTracerProvider_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WithTracerProvider'
+type MockManager_WithTracerProvider_Call struct {
 	*mock.Call
 }
 
-// WithDistributedLocker is a helper method to define mock.On call
-//   - locker gocron.Locker
-func (_e *MockManager_Expecter) WithDistributedLocker(locker interface{}) *MockManager_WithDistributedLocker_Call {
-	return &MockManager_WithDistributedLocker_Call{Call: _e.mock.On("WithDistributedLocker", locker)}
+// WithTracerProvider is a helper method to define mock.On call
+//   - provider trace.TracerProvider
+func (_e *MockManager_Expecter) WithTracerProvider(provider interface{}) *MockManager_WithTracerProvider_Call {
+	return &MockManager_WithTracerProvider_Call{Call: _e.mock.On("WithTracerProvider", provider)}
 }
 
-func (_c *MockManager_WithDistributedLocker_Call) Run(run func(locker gocron.Locker)) *MockManager_WithDistributedLocker_Call {
+func (_c *MockManager_WithTracerProvider_Call) Run(run func(provider trace.TracerProvider)) *MockManager_WithTracerProvider_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(gocron.Locker))
+		run(args[0].(trace.TracerProvider))
 	})
 	return _c
 }
 
-func (_c *MockManager_WithDistributedLocker_Call) Return(_a0 scheduler.Manager) *MockManager_WithDistributedLocker_Call {
+func (_c *MockManager_WithTracerProvider_Call) Return(_a0 scheduler.Manager) *MockManager_WithTracerProvider_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockManager_WithDistributedLocker_Call) RunAndReturn(run func(gocron.Locker) scheduler.Manager) *MockManager_WithDistributedLocker_Call {
+func (_c *MockManager_WithTracerProvider_Call) RunAndReturn(run func(trace.TracerProvider) scheduler.Manager) *MockManager_WithTracerProvider_Call {
 	_c.Call.Return(run)
 	return _c
 }
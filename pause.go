@@ -0,0 +1,155 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PauseState là tập các job và tag đang bị tạm dừng.
+type PauseState struct {
+	// Jobs là tên các job bị tạm dừng qua Manager.Pause
+	Jobs []string `json:"jobs"`
+
+	// Tags là các tag bị tạm dừng qua Manager.PauseByTag
+	Tags []string `json:"tags"`
+}
+
+// IsPaused kiểm tra job với name và tags có bị tạm dừng theo tên hoặc theo một trong các tag hay không.
+func (s PauseState) IsPaused(name string, tags []string) bool {
+	if slices.Contains(s.Jobs, name) {
+		return true
+	}
+	for _, tag := range tags {
+		if slices.Contains(s.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// PauseStore lưu trạng thái tạm dừng của job và tag.
+//
+// Manager đọc trạng thái qua Load trước mỗi lần chạy theo lịch, vì vậy các instance dùng chung
+// một PauseStore (ví dụ Redis hoặc SQL) cùng tạm dừng và tiếp tục job. Các phương thức phải
+// an toàn khi gọi đồng thời.
+type PauseStore interface {
+	// SetJobPaused tạm dừng (paused = true) hoặc tiếp tục job có tên name.
+	SetJobPaused(ctx context.Context, name string, paused bool) error
+
+	// SetTagPaused tạm dừng (paused = true) hoặc tiếp tục các job có tag.
+	SetTagPaused(ctx context.Context, tag string, paused bool) error
+
+	// Load trả về các job và tag đang bị tạm dừng.
+	Load(ctx context.Context) (PauseState, error)
+}
+
+// memoryPauseStore triển khai PauseStore trong bộ nhớ của process.
+type memoryPauseStore struct {
+	mu   sync.RWMutex
+	jobs map[string]bool
+	tags map[string]bool
+}
+
+// NewMemoryPauseStore tạo PauseStore trong bộ nhớ, được Manager sử dụng mặc định.
+//
+// Trạng thái bị mất khi process kết thúc và chỉ được chia sẻ giữa các Manager dùng chung store
+// trong một process; dùng NewRedisPauseStore hoặc NewSQLPauseStore khi chạy nhiều instance.
+func NewMemoryPauseStore() PauseStore {
+	return &memoryPauseStore{
+		jobs: make(map[string]bool),
+		tags: make(map[string]bool),
+	}
+}
+
+// SetJobPaused triển khai PauseStore.
+func (s *memoryPauseStore) SetJobPaused(ctx context.Context, name string, paused bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	toggle(s.jobs, name, paused)
+	return nil
+}
+
+// SetTagPaused triển khai PauseStore.
+func (s *memoryPauseStore) SetTagPaused(ctx context.Context, tag string, paused bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	toggle(s.tags, tag, paused)
+	return nil
+}
+
+// Load triển khai PauseStore.
+func (s *memoryPauseStore) Load(ctx context.Context) (PauseState, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return PauseState{Jobs: sortedKeys(s.jobs), Tags: sortedKeys(s.tags)}, nil
+}
+
+// toggle thêm key vào set nếu on là true, ngược lại xóa key khỏi set.
+func toggle(set map[string]bool, key string, on bool) {
+	if on {
+		set[key] = true
+	} else {
+		delete(set, key)
+	}
+}
+
+// sortedKeys trả về các key của set theo thứ tự tăng dần.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// pauseState trả về trạng thái tạm dừng từ PauseStore của Manager. Nếu không đọc được store,
+// lỗi được ghi log và trạng thái đọc được gần nhất được sử dụng.
+func (m *manager) pauseState() PauseState {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	state, err := m.pauses.Load(ctx)
+	if err != nil {
+		m.telemetry.logger().Warn("scheduler: failed to load pause state, using last known state", slog.Any("error", err))
+		if last := m.lastPause.Load(); last != nil {
+			return *last
+		}
+		return PauseState{}
+	}
+	m.lastPause.Store(&state)
+	return state
+}
+
+// setPaused ghi trạng thái tạm dừng của job (tag = false) hoặc tag vào PauseStore và phát
+// EventJobPaused hoặc EventJobResumed.
+func (m *manager) setPaused(name string, tag, paused bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var err error
+	if tag {
+		err = m.pauses.SetTagPaused(ctx, name, paused)
+	} else {
+		err = m.pauses.SetJobPaused(ctx, name, paused)
+	}
+	if err != nil {
+		return err
+	}
+
+	event := Event{Type: EventJobResumed}
+	if paused {
+		event.Type = EventJobPaused
+	}
+	if tag {
+		event.Tags = []string{name}
+	} else {
+		event.JobName = name
+	}
+	m.events.emit(event)
+	return nil
+}
@@ -0,0 +1,268 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// failingPauseStore là PauseStore có thể được chuyển sang trạng thái luôn trả về lỗi.
+type failingPauseStore struct {
+	PauseStore
+	failing atomic.Bool
+}
+
+func (s *failingPauseStore) Load(ctx context.Context) (PauseState, error) {
+	if s.failing.Load() {
+		return PauseState{}, errors.New("store unavailable")
+	}
+	return s.PauseStore.Load(ctx)
+}
+
+func (s *failingPauseStore) SetJobPaused(ctx context.Context, name string, paused bool) error {
+	if s.failing.Load() {
+		return errors.New("store unavailable")
+	}
+	return s.PauseStore.SetJobPaused(ctx, name, paused)
+}
+
+// countingPauseStore là PauseStore đếm số lần Load.
+type countingPauseStore struct {
+	PauseStore
+	loads atomic.Int32
+}
+
+func (s *countingPauseStore) Load(ctx context.Context) (PauseState, error) {
+	s.loads.Add(1)
+	return s.PauseStore.Load(ctx)
+}
+
+// testPauseStore kiểm tra ngữ nghĩa chung của các PauseStore.
+func testPauseStore(t *testing.T, store PauseStore) {
+	t.Helper()
+	ctx := context.Background()
+
+	state, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load pause state: %v", err)
+	}
+	assert.Empty(t, state.Jobs)
+	assert.Empty(t, state.Tags)
+
+	for _, name := range []string{"sync", "cleanup", "sync"} {
+		if err := store.SetJobPaused(ctx, name, true); err != nil {
+			t.Fatalf("Failed to pause job: %v", err)
+		}
+	}
+	if err := store.SetTagPaused(ctx, "billing", true); err != nil {
+		t.Fatalf("Failed to pause tag: %v", err)
+	}
+
+	state, err = store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load pause state: %v", err)
+	}
+	assert.Equal(t, []string{"cleanup", "sync"}, state.Jobs)
+	assert.Equal(t, []string{"billing"}, state.Tags)
+
+	if err := store.SetJobPaused(ctx, "sync", false); err != nil {
+		t.Fatalf("Failed to resume job: %v", err)
+	}
+	if err := store.SetTagPaused(ctx, "billing", false); err != nil {
+		t.Fatalf("Failed to resume tag: %v", err)
+	}
+	// Tiếp tục mục tiêu không bị tạm dừng không gây lỗi
+	if err := store.SetTagPaused(ctx, "reports", false); err != nil {
+		t.Fatalf("Failed to resume tag: %v", err)
+	}
+
+	state, err = store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Failed to load pause state: %v", err)
+	}
+	assert.Equal(t, []string{"cleanup"}, state.Jobs)
+	assert.Empty(t, state.Tags)
+}
+
+func TestMemoryPauseStore(t *testing.T) {
+	testPauseStore(t, NewMemoryPauseStore())
+}
+
+func TestPauseStateIsPaused(t *testing.T) {
+	state := PauseState{Jobs: []string{"sync"}, Tags: []string{"billing"}}
+
+	assert.True(t, state.IsPaused("sync", nil))
+	assert.True(t, state.IsPaused("invoice", []string{"reports", "billing"}))
+	assert.False(t, state.IsPaused("invoice", []string{"reports"}))
+	assert.False(t, PauseState{}.IsPaused("sync", []string{"billing"}))
+}
+
+func TestPauseStateSharedBetweenManagers(t *testing.T) {
+	store := NewMemoryPauseStore()
+	first := NewScheduler().WithPauseStore(store)
+	second := NewScheduler().WithPauseStore(store)
+
+	for _, scheduler := range []Manager{first, second} {
+		if _, err := scheduler.Every(1).Hours().Name("sync").Tag("billing").DoContext(func(ctx context.Context) error {
+			return nil
+		}); err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+	}
+
+	if err := first.Pause("sync"); err != nil {
+		t.Fatalf("Failed to pause job: %v", err)
+	}
+	assert.True(t, second.IsPaused("sync"))
+	assert.True(t, second.Jobs()[0].Paused)
+
+	if err := second.Resume("sync"); err != nil {
+		t.Fatalf("Failed to resume job: %v", err)
+	}
+	assert.False(t, first.IsPaused("sync"))
+
+	if err := second.PauseByTag("billing"); err != nil {
+		t.Fatalf("Failed to pause tag: %v", err)
+	}
+	assert.True(t, first.IsPaused("sync"))
+
+	state, err := first.PauseState(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to load pause state: %v", err)
+	}
+	assert.Equal(t, PauseState{Jobs: []string{}, Tags: []string{"billing"}}, state)
+}
+
+func TestPauseSurvivesSyncJobs(t *testing.T) {
+	scheduler := NewScheduler()
+
+	jobs := []JobConfig{{Name: "sync", Interval: "1h"}}
+	if err := scheduler.SyncJobs(jobs); err != nil {
+		t.Fatalf("Failed to sync jobs: %v", err)
+	}
+	if err := scheduler.Pause("sync"); err != nil {
+		t.Fatalf("Failed to pause job: %v", err)
+	}
+
+	// Job được lên lịch lại với cấu hình mới vẫn bị tạm dừng
+	jobs[0].Interval = "30m"
+	jobs[0].Tags = []string{"billing"}
+	if err := scheduler.SyncJobs(jobs); err != nil {
+		t.Fatalf("Failed to sync jobs: %v", err)
+	}
+	assert.True(t, scheduler.IsPaused("sync"))
+
+	// Job bị xóa rồi thêm lại vẫn bị tạm dừng
+	if err := scheduler.SyncJobs(nil); err != nil {
+		t.Fatalf("Failed to sync jobs: %v", err)
+	}
+	if err := scheduler.SyncJobs(jobs); err != nil {
+		t.Fatalf("Failed to sync jobs: %v", err)
+	}
+	assert.True(t, scheduler.Jobs()[0].Paused)
+
+	// Job mới có tag đang bị tạm dừng cũng bị tạm dừng
+	if err := scheduler.Resume("sync"); err != nil {
+		t.Fatalf("Failed to resume job: %v", err)
+	}
+	if err := scheduler.PauseByTag("billing"); err != nil {
+		t.Fatalf("Failed to pause tag: %v", err)
+	}
+	jobs = append(jobs, JobConfig{Name: "invoice", Interval: "1h", Tags: []string{"billing"}})
+	if err := scheduler.SyncJobs(jobs); err != nil {
+		t.Fatalf("Failed to sync jobs: %v", err)
+	}
+	assert.True(t, scheduler.IsPaused("invoice"))
+}
+
+func TestPauseEmitsEvents(t *testing.T) {
+	scheduler := NewScheduler()
+	if _, err := scheduler.Every(1).Hours().Name("sync").Tag("billing").DoContext(func(ctx context.Context) error {
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	var mu sync.Mutex
+	var events []Event
+	scheduler.OnEvent(func(event Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		if event.Type == EventJobPaused || event.Type == EventJobResumed {
+			events = append(events, event)
+		}
+	})
+
+	assert.NoError(t, scheduler.Pause("sync"))
+	assert.NoError(t, scheduler.ResumeByTag("billing"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	assert.Equal(t, EventJobPaused, events[0].Type)
+	assert.Equal(t, "sync", events[0].JobName)
+	assert.Equal(t, EventJobResumed, events[1].Type)
+	assert.Equal(t, []string{"billing"}, events[1].Tags)
+}
+
+func TestPauseStoreFailures(t *testing.T) {
+	store := &failingPauseStore{PauseStore: NewMemoryPauseStore()}
+	scheduler := NewScheduler().WithPauseStore(store)
+
+	var calls atomic.Int32
+	if _, err := scheduler.Every(20 * time.Millisecond).Name("poll").DoContext(func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if err := scheduler.Pause("poll"); err != nil {
+		t.Fatalf("Failed to pause job: %v", err)
+	}
+	assert.True(t, scheduler.IsPaused("poll"))
+
+	// Store không đọc được: trạng thái đọc được gần nhất được sử dụng
+	store.failing.Store(true)
+	assert.Error(t, scheduler.Resume("poll"))
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), calls.Load(), "job should stay paused while the store is unavailable")
+	assert.True(t, scheduler.IsPaused("poll"))
+}
+
+func TestPauseStateLoadedOncePerRun(t *testing.T) {
+	for _, withLocker := range []bool{false, true} {
+		store := &countingPauseStore{PauseStore: NewMemoryPauseStore()}
+		scheduler := NewScheduler().WithPauseStore(store)
+		if withLocker {
+			scheduler.WithDistributedLocker(&recordingLocker{lock: &unlockCountingLock{}})
+		}
+
+		done := make(chan struct{}, 1)
+		if _, err := scheduler.Every(1).Hours().Name("sync").Tag("billing").DoContext(func(ctx context.Context) error {
+			done <- struct{}{}
+			return nil
+		}); err != nil {
+			t.Fatalf("Failed to schedule job: %v", err)
+		}
+
+		scheduler.StartAsync()
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Expected job to run")
+		}
+		scheduler.Stop()
+
+		assert.Equal(t, int32(1), store.loads.Load(), "withLocker=%v", withLocker)
+	}
+}
@@ -0,0 +1,189 @@
+package scheduler
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusCollector là MetricsCollector ghi metrics vào Prometheus.
+//
+// Các metrics (với namespace mặc định "scheduler"):
+//   - scheduler_job_runs_total{job,tags,status}: số lần chạy, status là "succeeded" hoặc "failed"
+//   - scheduler_job_failures_total{job,tags}: số lần chạy thất bại
+//   - scheduler_job_panics_total{job,tags}: số lần chạy có hàm job panic
+//   - scheduler_job_duration_seconds{job,tags}: histogram thời gian chạy
+//   - scheduler_job_running{job,tags}: số lần chạy đang diễn ra
+//   - scheduler_job_skipped_total{job}: số lần chạy bị bỏ qua vì không lấy được khóa phân tán
+//   - scheduler_lock_acquire_duration_seconds{job,result}: histogram thời gian lấy khóa, result là "acquired" hoặc "skipped"
+//   - scheduler_lock_renew_failures_total{job}: số lần gia hạn khóa thất bại
+//
+// Label tags là các tag của job nối bằng dấu phẩy.
+type PrometheusCollector struct {
+	runs          *prometheus.CounterVec
+	failures      *prometheus.CounterVec
+	panics        *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	running       *prometheus.GaugeVec
+	skipped       *prometheus.CounterVec
+	lockLatency   *prometheus.HistogramVec
+	renewFailures *prometheus.CounterVec
+}
+
+// NewPrometheusCollector tạo PrometheusCollector và đăng ký các metrics vào registerer.
+//
+// Nếu registerer là nil, prometheus.DefaultRegisterer được sử dụng; namespace trống tương đương
+// "scheduler". Nếu các metrics đã được đăng ký (ví dụ bởi Manager khác), metrics hiện có được
+// dùng chung.
+//
+// Example:
+//
+//	collector, err := scheduler.NewPrometheusCollector(prometheus.DefaultRegisterer, "")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	manager.WithMetrics(collector)
+func NewPrometheusCollector(registerer prometheus.Registerer, namespace string) (*PrometheusCollector, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	if namespace == "" {
+		namespace = "scheduler"
+	}
+
+	c := &PrometheusCollector{
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_runs_total",
+			Help:      "Total number of job runs by status.",
+		}, []string{"job", "tags", "status"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_failures_total",
+			Help:      "Total number of failed job runs.",
+		}, []string{"job", "tags"}),
+		panics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_panics_total",
+			Help:      "Total number of job runs that panicked.",
+		}, []string{"job", "tags"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "job_duration_seconds",
+			Help:      "Duration of job runs in seconds.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"job", "tags"}),
+		running: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "job_running",
+			Help:      "Number of job runs currently in progress.",
+		}, []string{"job", "tags"}),
+		skipped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_skipped_total",
+			Help:      "Total number of job runs skipped because the distributed lock was not acquired.",
+		}, []string{"job"}),
+		lockLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "lock_acquire_duration_seconds",
+			Help:      "Time spent acquiring distributed locks in seconds.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 12),
+		}, []string{"job", "result"}),
+		renewFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "lock_renew_failures_total",
+			Help:      "Total number of failed distributed lock renewals.",
+		}, []string{"job"}),
+	}
+
+	var err error
+	if c.runs, err = registerVec(registerer, c.runs); err != nil {
+		return nil, err
+	}
+	if c.failures, err = registerVec(registerer, c.failures); err != nil {
+		return nil, err
+	}
+	if c.panics, err = registerVec(registerer, c.panics); err != nil {
+		return nil, err
+	}
+	if c.duration, err = registerVec(registerer, c.duration); err != nil {
+		return nil, err
+	}
+	if c.running, err = registerVec(registerer, c.running); err != nil {
+		return nil, err
+	}
+	if c.skipped, err = registerVec(registerer, c.skipped); err != nil {
+		return nil, err
+	}
+	if c.lockLatency, err = registerVec(registerer, c.lockLatency); err != nil {
+		return nil, err
+	}
+	if c.renewFailures, err = registerVec(registerer, c.renewFailures); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// registerVec đăng ký collector vào registerer, trả về collector đã đăng ký trước đó nếu có.
+func registerVec[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	if err := registerer.Register(collector); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if errors.As(err, &already) {
+			if existing, ok := already.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+		return collector, err
+	}
+	return collector, nil
+}
+
+// JobStarted triển khai MetricsCollector.
+func (c *PrometheusCollector) JobStarted(job string, tags []string) {
+	c.running.WithLabelValues(job, joinTags(tags)).Inc()
+}
+
+// JobFinished triển khai MetricsCollector.
+func (c *PrometheusCollector) JobFinished(job string, tags []string, duration time.Duration, err error) {
+	tagLabel := joinTags(tags)
+	c.running.WithLabelValues(job, tagLabel).Dec()
+	c.duration.WithLabelValues(job, tagLabel).Observe(duration.Seconds())
+
+	status := "succeeded"
+	if err != nil {
+		status = "failed"
+		c.failures.WithLabelValues(job, tagLabel).Inc()
+	}
+	c.runs.WithLabelValues(job, tagLabel, status).Inc()
+}
+
+// JobPanicked triển khai MetricsCollector.
+func (c *PrometheusCollector) JobPanicked(job string, tags []string) {
+	c.panics.WithLabelValues(job, joinTags(tags)).Inc()
+}
+
+// JobSkipped triển khai MetricsCollector.
+func (c *PrometheusCollector) JobSkipped(job string) {
+	c.skipped.WithLabelValues(job).Inc()
+}
+
+// LockAcquired triển khai MetricsCollector.
+func (c *PrometheusCollector) LockAcquired(key string, latency time.Duration, acquired bool) {
+	result := "acquired"
+	if !acquired {
+		result = "skipped"
+	}
+	c.lockLatency.WithLabelValues(key, result).Observe(latency.Seconds())
+}
+
+// LockRenewFailed triển khai MetricsCollector.
+func (c *PrometheusCollector) LockRenewFailed(key string) {
+	c.renewFailures.WithLabelValues(key).Inc()
+}
+
+// joinTags trả về giá trị label tags của job.
+func joinTags(tags []string) string {
+	return strings.Join(tags, ",")
+}
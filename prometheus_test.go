@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestPrometheusCollector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	collector, err := NewPrometheusCollector(registry, "")
+	if err != nil {
+		t.Fatalf("Failed to create collector: %v", err)
+	}
+
+	tags := []string{"orders", "sync"}
+	collector.JobStarted("sync", tags)
+	collector.JobStarted("sync", tags)
+	if got := testutil.ToFloat64(collector.running.WithLabelValues("sync", "orders,sync")); got != 2 {
+		t.Errorf("Expected 2 running jobs, got %v", got)
+	}
+
+	collector.JobFinished("sync", tags, 100*time.Millisecond, nil)
+	collector.JobFinished("sync", tags, 200*time.Millisecond, errors.New("failed"))
+	collector.JobPanicked("sync", tags)
+	collector.JobSkipped("sync")
+	collector.LockAcquired("sync", time.Millisecond, true)
+	collector.LockAcquired("sync", time.Millisecond, false)
+	collector.LockRenewFailed("sync")
+
+	checks := map[string]float64{
+		"running":   testutil.ToFloat64(collector.running.WithLabelValues("sync", "orders,sync")),
+		"succeeded": testutil.ToFloat64(collector.runs.WithLabelValues("sync", "orders,sync", "succeeded")),
+		"failed":    testutil.ToFloat64(collector.runs.WithLabelValues("sync", "orders,sync", "failed")),
+		"failures":  testutil.ToFloat64(collector.failures.WithLabelValues("sync", "orders,sync")),
+		"panics":    testutil.ToFloat64(collector.panics.WithLabelValues("sync", "orders,sync")),
+		"skipped":   testutil.ToFloat64(collector.skipped.WithLabelValues("sync")),
+		"renew":     testutil.ToFloat64(collector.renewFailures.WithLabelValues("sync")),
+	}
+	expected := map[string]float64{"running": 0, "succeeded": 1, "failed": 1, "failures": 1, "panics": 1, "skipped": 1, "renew": 1}
+	for name, want := range expected {
+		if checks[name] != want {
+			t.Errorf("Expected %s = %v, got %v", name, want, checks[name])
+		}
+	}
+
+	if n := testutil.CollectAndCount(collector.duration, "scheduler_job_duration_seconds"); n != 1 {
+		t.Errorf("Expected 1 duration series, got %d", n)
+	}
+	if n := testutil.CollectAndCount(collector.lockLatency, "scheduler_lock_acquire_duration_seconds"); n != 2 {
+		t.Errorf("Expected acquired and skipped latency series, got %d", n)
+	}
+}
+
+func TestPrometheusCollectorReusesRegisteredMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first, err := NewPrometheusCollector(registry, "app")
+	if err != nil {
+		t.Fatalf("Failed to create collector: %v", err)
+	}
+	second, err := NewPrometheusCollector(registry, "app")
+	if err != nil {
+		t.Fatalf("Expected second collector to reuse metrics, got %v", err)
+	}
+
+	first.JobSkipped("sync")
+	second.JobSkipped("sync")
+	if got := testutil.ToFloat64(first.skipped.WithLabelValues("sync")); got != 2 {
+		t.Errorf("Expected shared counter, got %v", got)
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() == "app_job_skipped_total" {
+			return
+		}
+	}
+	t.Error("Expected metrics with custom namespace")
+}
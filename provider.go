@@ -1,11 +1,28 @@
 package scheduler
 
 import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.fork.vn/config"
 	"go.fork.vn/di"
-	"go.fork.vn/redis"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ShutdownRegistrar là interface tùy chọn của application cho phép đăng ký các hàm được gọi khi
+// ứng dụng dừng. Nếu application implement ShutdownRegistrar, Boot đăng ký Terminate của
+// ServiceProvider để scheduler được dừng cùng ứng dụng.
+type ShutdownRegistrar interface {
+	// OnShutdown đăng ký fn, được gọi với context giới hạn thời gian dừng của ứng dụng
+	OnShutdown(fn func(ctx context.Context) error)
+}
+
 // ServiceProvider cung cấp dịch vụ scheduler và tích hợp với DI container.
 //
 // ServiceProvider là một implementation của interface di.ServiceProvider, cho phép tự động
@@ -18,7 +35,11 @@ import (
 // Để sử dụng ServiceProvider, ứng dụng cần:
 //   - Implement interface Container() *di.Container để cung cấp DI container
 type ServiceProvider struct {
-	providers []string
+	providers       []string
+	requires        []string      // Các dependency của locker backend đã chọn
+	configured      bool          // Register đã load cấu hình và xác định requires
+	shutdownTimeout time.Duration // Thời gian chờ các job đang chạy khi Terminate
+	jobs            []JobConfig   // Các job khai báo trong cấu hình đã được lên lịch
 }
 
 // NewServiceProvider trả về một ServiceProvider mới cho module scheduler.
@@ -27,12 +48,13 @@ type ServiceProvider struct {
 // ServiceProvider cho phép tự động đăng ký và cấu hình scheduler manager cho ứng dụng.
 //
 // Returns:
-//   - di.ServiceProvider: Interface di.ServiceProvider đã được implement bởi ServiceProvider
+//   - *ServiceProvider: ServiceProvider implement di.ServiceProvider, kèm Terminate cho giai
+//     đoạn termination của ứng dụng
 //
 // Example:
 //
 //	app.Register(scheduler.NewServiceProvider())
-func NewServiceProvider() di.ServiceProvider {
+func NewServiceProvider() *ServiceProvider {
 	return &ServiceProvider{}
 }
 
@@ -46,19 +68,42 @@ func NewServiceProvider() di.ServiceProvider {
 //
 // Luồng thực thi:
 //  1. Lấy container từ app
-//  2. Load cấu hình scheduler
-//  3. Tạo scheduler manager mới
-//  4. Cấu hình distributed locking nếu được bật
-//  5. Đăng ký scheduler manager vào container với key "scheduler"
+//  2. Load cấu hình scheduler và kiểm tra tính hợp lệ
+//  3. Tạo scheduler manager mới với timezone và các tùy chọn cấp scheduler; nếu scheduler.logger
+//     được thiết lập, *slog.Logger với key đó trong container được dùng để ghi log; nếu
+//     scheduler.metrics.enabled được bật, metrics được thu thập qua PrometheusCollector; nếu
+//     scheduler.tracing.enabled được bật, span được tạo qua trace.TracerProvider; nếu
+//     scheduler.history.enabled được bật, lịch sử chạy job được lưu qua HistoryStore
+//  4. Cấu hình distributed locking nếu được bật, với locker backend theo distributed_lock.backend
+//     và chế độ khóa theo từng job hoặc leader election theo distributed_lock.mode; nếu
+//     distributed_lock.share_pause_state được bật, trạng thái tạm dừng được lưu trong backend
+//  5. Lên lịch các job khai báo trong scheduler.jobs (bỏ qua các job có enabled: false)
+//  6. Nếu scheduler.watch_jobs được bật, theo dõi file cấu hình và gọi Manager.SyncJobs
+//     với scheduler.jobs mới mỗi khi file thay đổi
+//  7. Đăng ký scheduler manager vào container với key "scheduler"
 //
 // Việc cấu hình và đăng ký các task sẽ được thực hiện bởi ứng dụng,
 // cho phép mỗi ứng dụng tùy chỉnh scheduler theo nhu cầu riêng.
 //
 // Panics:
 //   - Nếu không thể lấy container từ application
+//   - Nếu cấu hình scheduler không hợp lệ (ví dụ timezone không tồn tại)
 //   - Nếu không thể tạo scheduler manager
 //   - Nếu không thể đăng ký scheduler vào container
-//   - Nếu distributed locking được bật nhưng không thể cấu hình Redis locker
+//   - Nếu distributed locking được bật nhưng không thể cấu hình Redis hoặc SQL locker
+//   - Nếu không thể lên lịch một job khai báo trong cấu hình
+//   - Nếu scheduler.logger được thiết lập nhưng không resolve được thành *slog.Logger
+//   - Nếu metrics được bật nhưng không resolve được prometheus.Registerer hoặc không đăng ký được metrics
+//   - Nếu tracing được bật nhưng không resolve được trace.TracerProvider
+//   - Nếu lịch sử được bật nhưng không tạo được HistoryStore
+//   - Nếu share_pause_state được bật nhưng không tạo được PauseStore
+//
+// Handler của các job khai báo trong cấu hình được tra cứu theo tên trong Manager.Registry()
+// khi job chạy, vì vậy các service provider khác có thể đăng ký handler trong Register của mình.
+// Boot kiểm tra tất cả các handler được tham chiếu đã được đăng ký.
+//
+// Locker được tạo bởi backend đã đăng ký qua RegisterLockerBackend. Các backend có sẵn là
+// "redis", "postgres", "mysql", "sqlite" và "memory"; xem RegisterLockerBackend.
 func (p *ServiceProvider) Register(app di.Application) {
 	container := app.Container()
 	if container == nil {
@@ -69,47 +114,178 @@ func (p *ServiceProvider) Register(app di.Application) {
 	cfg := DefaultConfig()
 
 	// Thử lấy cấu hình từ config provider (optional)
+	var configManager config.Manager
 	if configInstance, err := container.Make("config"); err == nil {
-		if configManager, ok := configInstance.(config.Manager); ok {
-			// Load cấu hình từ file config với error handling
-			if err := configManager.UnmarshalKey("scheduler", &cfg); err != nil {
-				panic("scheduler: failed to load scheduler configuration: " + err.Error())
-			}
+		configManager, _ = configInstance.(config.Manager)
+	}
+	if configManager != nil {
+		// Load cấu hình từ file config với error handling
+		if err := configManager.UnmarshalKey("scheduler", &cfg); err != nil {
+			panic("scheduler: failed to load scheduler configuration: " + err.Error())
 		}
 	}
 
-	// Tạo scheduler manager với cấu hình
+	// Kiểm tra tính hợp lệ của cấu hình trước khi tạo scheduler
+	if err := cfg.Validate(); err != nil {
+		panic("scheduler: invalid scheduler configuration: " + err.Error())
+	}
+
+	// Tạo scheduler manager với cấu hình (timezone, giới hạn job đồng thời, ...)
 	manager := NewSchedulerWithConfig(cfg)
 	if manager == nil {
 		panic("scheduler: failed to create scheduler manager with config")
 	}
 
-	// Cấu hình distributed locking nếu được bật
-	if cfg.DistributedLock.Enabled {
-		redisInstance, err := container.Make("redis")
+	p.shutdownTimeout = time.Duration(cfg.ShutdownTimeout) * time.Second
+
+	// Logger của ứng dụng được resolve từ container nếu scheduler.logger được thiết lập
+	logger := slog.Default()
+	if cfg.Logger != "" {
+		instance, err := container.Make(cfg.Logger)
 		if err != nil {
-			panic("scheduler: distributed locking is enabled but redis service not found: " + err.Error())
+			panic("scheduler: logger " + cfg.Logger + " not found in container: " + err.Error())
 		}
+		var ok bool
+		if logger, ok = instance.(*slog.Logger); !ok || logger == nil {
+			panic("scheduler: logger " + cfg.Logger + " is not a *slog.Logger")
+		}
+		manager = manager.WithLogger(logger)
+	}
+	level, _ := cfg.GetLogLevel()
+	logger = withLevel(logger, level)
 
-		redisManager, ok := redisInstance.(redis.Manager)
-		if !ok {
-			panic("scheduler: redis service is not a valid redis.Manager interface")
+	// Thu thập metrics với Prometheus nếu được bật
+	if cfg.Metrics.Enabled {
+		registerer := prometheus.DefaultRegisterer
+		if cfg.Metrics.Registerer != "" {
+			instance, err := container.Make(cfg.Metrics.Registerer)
+			if err != nil {
+				panic("scheduler: metrics registerer " + cfg.Metrics.Registerer + " not found in container: " + err.Error())
+			}
+			var ok bool
+			if registerer, ok = instance.(prometheus.Registerer); !ok {
+				panic("scheduler: metrics registerer " + cfg.Metrics.Registerer + " is not a prometheus.Registerer")
+			}
 		}
 
-		redisClient, err := redisManager.Client()
+		collector, err := NewPrometheusCollector(registerer, cfg.Metrics.Namespace)
 		if err != nil {
-			panic("scheduler: failed to get redis client for distributed locking: " + err.Error())
+			logger.Error("scheduler: failed to register metrics", slog.Any("error", err))
+			panic("scheduler: failed to register metrics: " + err.Error())
 		}
+		manager = manager.WithMetrics(collector)
+	}
+
+	// Tạo span OpenTelemetry nếu được bật
+	if cfg.Tracing.Enabled {
+		provider := otel.GetTracerProvider()
+		if cfg.Tracing.TracerProvider != "" {
+			instance, err := container.Make(cfg.Tracing.TracerProvider)
+			if err != nil {
+				panic("scheduler: tracer provider " + cfg.Tracing.TracerProvider + " not found in container: " + err.Error())
+			}
+			var ok bool
+			if provider, ok = instance.(trace.TracerProvider); !ok {
+				panic("scheduler: tracer provider " + cfg.Tracing.TracerProvider + " is not a trace.TracerProvider")
+			}
+		}
+		manager = manager.WithTracerProvider(provider)
+	}
 
-		locker, err := NewRedisLocker(redisClient, cfg.Options)
+	// Handler đăng ký qua RegisterBinding được resolve từ container của ứng dụng
+	manager.Registry().SetContainer(container)
+
+	// Lưu lịch sử các lần chạy job nếu được bật
+	p.requires = nil
+	p.configured = true
+	if cfg.History.Enabled {
+		store, err := newHistoryStoreFromContainer(container, cfg.History)
 		if err != nil {
-			panic("scheduler: failed to create Redis locker: " + err.Error())
+			logger.Error("scheduler: failed to create history store", slog.String("driver", cfg.History.DriverName()), slog.Any("error", err))
+			panic("scheduler: failed to create history store: " + err.Error())
+		}
+		manager = manager.WithHistory(store)
+		if cfg.History.DriverName() == HistoryDriverRedis {
+			p.require("redis")
+		}
+	}
+
+	// Cấu hình distributed locking nếu được bật
+	if cfg.DistributedLock.Enabled {
+		name := cfg.DistributedLock.BackendName()
+		backend, ok := lookupLockerBackend(name)
+		if !ok {
+			logger.Error("scheduler: locker backend is not registered", slog.String("backend", name))
+			panic("scheduler: distributed locking is enabled but locker backend " + name + " is not registered")
 		}
+		p.require(backend.Requires...)
+
+		if cfg.DistributedLock.Mode == LockModeLeaderElection {
+			if backend.Elector == nil {
+				panic("scheduler: locker backend " + name + " does not support leader election")
+			}
+
+			elector, err := backend.Elector(container, cfg)
+			if err != nil {
+				logger.Error("scheduler: failed to create leader elector", slog.String("backend", name), slog.Any("error", err))
+				panic("scheduler: failed to create " + name + " leader elector: " + err.Error())
+			}
 
-		manager = manager.WithDistributedLocker(locker)
+			manager = manager.WithLeaderElector(elector)
+		} else {
+			locker, err := backend.Factory(container, cfg)
+			if err != nil {
+				logger.Error("scheduler: failed to create locker", slog.String("backend", name), slog.Any("error", err))
+				panic("scheduler: failed to create " + name + " locker: " + err.Error())
+			}
+
+			manager = manager.WithDistributedLocker(locker)
+		}
 		if manager == nil {
-			panic("scheduler: failed to configure distributed locker on scheduler manager")
+			panic("scheduler: failed to configure distributed locking on scheduler manager")
+		}
+
+		if cfg.DistributedLock.SharePauseState {
+			if backend.PauseStore == nil {
+				panic("scheduler: locker backend " + name + " does not support shared pause state")
+			}
+
+			store, err := backend.PauseStore(container, cfg)
+			if err != nil {
+				logger.Error("scheduler: failed to create pause store", slog.String("backend", name), slog.Any("error", err))
+				panic("scheduler: failed to create " + name + " pause store: " + err.Error())
+			}
+
+			manager = manager.WithPauseStore(store)
+		}
+	}
+
+	// Lên lịch các job khai báo trong cấu hình
+	p.jobs = nil
+	for _, job := range cfg.Jobs {
+		if !job.IsEnabled() {
+			continue
+		}
+		if _, err := manager.ScheduleJob(job); err != nil {
+			logger.Error("scheduler: failed to schedule configured job", slog.String("job", job.Name), slog.Any("error", err))
+			panic("scheduler: failed to schedule job " + job.Name + ": " + err.Error())
 		}
+		p.jobs = append(p.jobs, job)
+	}
+
+	// Đối chiếu lại các job khi file cấu hình thay đổi
+	if cfg.WatchJobs && configManager != nil {
+		configManager.OnConfigChange(func(fsnotify.Event) {
+			reloaded := DefaultConfig()
+			if err := configManager.UnmarshalKey("scheduler", &reloaded); err != nil {
+				// Giữ nguyên các job hiện tại khi không đọc được cấu hình mới
+				logger.Error("scheduler: failed to reload configured jobs", slog.Any("error", err))
+				return
+			}
+			// Kết quả được ghi log qua EventJobsSynced
+			_ = manager.SyncJobs(reloaded.Jobs)
+		})
+		configManager.WatchConfig()
 	}
 
 	// Đăng ký scheduler manager vào container
@@ -125,8 +301,10 @@ func (p *ServiceProvider) Register(app di.Application) {
 //
 // Trong trường hợp của SchedulerServiceProvider, Boot thực hiện:
 // 1. Lấy scheduler manager từ container
-// 2. Load cấu hình scheduler để kiểm tra AutoStart
-// 3. Tự động start scheduler nếu AutoStart được bật
+// 2. Kiểm tra handler của các job khai báo trong cấu hình đã được đăng ký
+// 3. Nếu app implement ShutdownRegistrar, đăng ký dừng scheduler như Terminate khi ứng dụng dừng
+// 4. Load cấu hình scheduler để kiểm tra AutoStart
+// 5. Tự động start scheduler nếu AutoStart được bật
 //
 // Params:
 //   - app: di.Application - Đối tượng ứng dụng implements di.Application interface
@@ -135,6 +313,7 @@ func (p *ServiceProvider) Register(app di.Application) {
 //   - Nếu không thể lấy container từ application
 //   - Nếu không tìm thấy scheduler trong container
 //   - Nếu scheduler không đúng type Manager interface
+//   - Nếu một job khai báo trong cấu hình tham chiếu tới handler chưa được đăng ký
 //   - Nếu không thể load cấu hình scheduler
 //   - Nếu AutoStart được bật nhưng không thể start scheduler
 func (p *ServiceProvider) Boot(app di.Application) {
@@ -154,6 +333,20 @@ func (p *ServiceProvider) Boot(app di.Application) {
 		panic("scheduler: registered scheduler service is not a valid Manager interface")
 	}
 
+	// Các handler phải được đăng ký trong Register của các service provider
+	for _, job := range p.jobs {
+		if !scheduler.Registry().Has(job.HandlerName()) {
+			panic("scheduler: handler " + job.HandlerName() + " for job " + job.Name + " is not registered")
+		}
+	}
+
+	// Dừng scheduler cùng ứng dụng nếu application hỗ trợ đăng ký hàm khi dừng
+	if registrar, ok := app.(ShutdownRegistrar); ok {
+		registrar.OnShutdown(func(ctx context.Context) error {
+			return p.shutdown(ctx, scheduler)
+		})
+	}
+
 	// Kiểm tra xem scheduler đã được start chưa
 	if scheduler.IsRunning() {
 		return // Scheduler đã được start rồi, không cần làm gì thêm
@@ -184,13 +377,93 @@ func (p *ServiceProvider) Boot(app di.Application) {
 	}
 }
 
+// Terminate dừng scheduler khi ứng dụng kết thúc (ví dụ khi nhận SIGTERM).
+//
+// Terminate là hook cho giai đoạn termination của ứng dụng, được gọi sau khi ứng dụng ngừng
+// nhận request mới. Phương thức này gọi Manager.Shutdown với thời gian chờ shutdown_timeout
+// trong cấu hình: các job đang chạy được chờ kết thúc, sau thời gian chờ context của chúng bị
+// hủy và các khóa phân tán được giải phóng.
+//
+// Nếu application implement ShutdownRegistrar, Boot đã đăng ký hàm tương đương với Terminate
+// và ứng dụng không cần gọi Terminate.
+//
+// Params:
+//   - app: di.Application - Đối tượng ứng dụng implements di.Application interface
+//
+// Returns:
+//   - error: *ShutdownError nếu vẫn còn job đang chạy khi hết thời gian chờ, hoặc lỗi nếu
+//     không tìm thấy scheduler trong container
+//
+// Example:
+//
+//	<-signals // SIGTERM
+//	if err := provider.Terminate(app); err != nil {
+//		log.Printf("scheduler shutdown: %v", err)
+//	}
+func (p *ServiceProvider) Terminate(app di.Application) error {
+	container := app.Container()
+	if container == nil {
+		return ErrContainerNil
+	}
+
+	instance, err := container.Make("scheduler")
+	if err != nil {
+		return err
+	}
+
+	scheduler, ok := instance.(Manager)
+	if !ok {
+		return ErrInvalidManager
+	}
+
+	return p.shutdown(context.Background(), scheduler)
+}
+
+// shutdown gọi Manager.Shutdown, chờ các job đang chạy tối đa shutdown_timeout và không quá
+// thời hạn của ctx.
+func (p *ServiceProvider) shutdown(ctx context.Context, scheduler Manager) error {
+	if p.shutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.shutdownTimeout)
+		defer cancel()
+	}
+	return scheduler.Shutdown(ctx)
+}
+
+// Requires trả về danh sách service provider mà scheduler phụ thuộc.
+//
+// Ngoài "config", chỉ các dependency của locker backend và history driver được chọn
+// (ví dụ "redis") được khai báo. Backend được xác định khi Register load cấu hình; nếu
+// distributed locking và lịch sử với Redis không được bật, scheduler chỉ phụ thuộc vào "config".
+//
+// Trước khi Register chạy, cấu hình chưa được biết nên Requires khai báo cả "redis" của backend
+// mặc định, để ứng dụng sắp xếp thứ tự Register theo Requires vẫn Register redis provider trước
+// scheduler. Sau Register, Requires chỉ còn các dependency của cấu hình đã load.
 func (p *ServiceProvider) Requires() []string {
-	return []string{
-		"config",
-		"redis",
+	if !p.configured {
+		return []string{"config", LockDriverRedis}
+	}
+	return append([]string{"config"}, p.requires...)
+}
+
+// require thêm các dependency vào Requires, bỏ qua các dependency đã được khai báo.
+func (p *ServiceProvider) require(names ...string) {
+	for _, name := range names {
+		if !slices.Contains(p.requires, name) {
+			p.requires = append(p.requires, name)
+		}
 	}
 }
 
 func (p *ServiceProvider) Providers() []string {
 	return p.providers
 }
+
+// Error constants cho ServiceProvider
+var (
+	// ErrContainerNil được trả về khi application không cung cấp DI container.
+	ErrContainerNil = errors.New("scheduler: DI container is nil")
+
+	// ErrInvalidManager được trả về khi service "scheduler" trong container không phải Manager.
+	ErrInvalidManager = errors.New("scheduler: registered scheduler service is not a valid Manager interface")
+)
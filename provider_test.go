@@ -1,17 +1,35 @@
 package scheduler
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/alicebob/miniredis/v2"
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	configMocks "go.fork.vn/config/mocks"
 	"go.fork.vn/di"
 	diMocks "go.fork.vn/di/mocks"
+	forkredis "go.fork.vn/redis"
 	redisMocks "go.fork.vn/redis/mocks"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
+// universalRedisManager bổ sung UniversalClient cho redis.Manager giả lập.
+type universalRedisManager struct {
+	forkredis.Manager
+	client redis.UniversalClient
+}
+
+func (m *universalRedisManager) UniversalClient() (redis.UniversalClient, error) {
+	return m.client, nil
+}
+
 func TestServiceProviderRegister(t *testing.T) {
 	// Tạo mock objects
 	mockApp := diMocks.NewMockApplication(t)
@@ -99,6 +117,113 @@ func TestServiceProviderRegisterWithDistributedLock(t *testing.T) {
 	mockRedis.AssertExpectations(t)
 }
 
+func TestServiceProviderRegisterWithUniversalRedisClient(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	server := miniredis.RunT(t)
+	ring := redis.NewRing(&redis.RingOptions{Addrs: map[string]string{"shard1": server.Addr()}})
+	defer ring.Close()
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.RedisClient = RedisClientUniversal
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Make("redis").Return(&universalRedisManager{client: ring}, nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager"))
+
+	provider := NewServiceProvider()
+	assert.NotPanics(t, func() {
+		provider.Register(mockApp)
+	})
+}
+
+func TestServiceProviderRegisterWithSQLLockDriver(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	db := newTestSQLiteDB(t)
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+	cfg.DistributedLock.Driver = LockDriverSQLite
+	cfg.DistributedLock.Database = "database.default"
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Make("database.default").Return(db, nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager"))
+
+	provider := NewServiceProvider()
+	assert.NotPanics(t, func() {
+		provider.Register(mockApp)
+	})
+}
+
+func TestServiceProviderRegisterWithTimezone(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.Timezone = "Asia/Ho_Chi_Minh"
+
+	var registered Manager
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager")).Run(func(key string, instance interface{}) {
+		registered = instance.(Manager)
+	})
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	assert.NotNil(t, registered)
+	assert.Equal(t, "Asia/Ho_Chi_Minh", registered.GetScheduler().Location().String())
+}
+
+func TestServiceProviderRegisterWithInvalidTimezone(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.Timezone = "Invalid/Zone"
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+
+	provider := NewServiceProvider()
+
+	assert.Panics(t, func() {
+		provider.Register(mockApp)
+	})
+}
+
 func TestServiceProviderRegisterPanics(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -126,6 +251,221 @@ func TestServiceProviderRegisterPanics(t *testing.T) {
 	}
 }
 
+func TestServiceProviderRegisterWithLogger(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.Logger = "slog"
+	logger, buf := newTestLogger()
+
+	var registered Manager
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockContainer.EXPECT().Make("slog").Return(logger, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager")).Run(func(abstract string, instance interface{}) {
+		registered = instance.(Manager)
+	})
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	registered.StartAsync()
+	registered.Stop()
+	assert.Contains(t, buf.String(), `msg="scheduler: started"`)
+}
+
+func TestServiceProviderRegisterPanicsOnInvalidLogger(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.Logger = "slog"
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockContainer.EXPECT().Make("slog").Return("not a logger", nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+
+	provider := NewServiceProvider()
+
+	assert.PanicsWithValue(t, "scheduler: logger slog is not a *slog.Logger", func() {
+		provider.Register(mockApp)
+	})
+}
+
+func TestServiceProviderRegisterWithMetrics(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.Metrics = MetricsConfig{Enabled: true, Namespace: "app", Registerer: "prometheus"}
+	registry := prometheus.NewRegistry()
+
+	var registered Manager
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockContainer.EXPECT().Make("prometheus").Return(registry, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager")).Run(func(abstract string, instance interface{}) {
+		registered = instance.(Manager)
+	})
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	collector, ok := registered.(*manager).telemetry.metrics().(*PrometheusCollector)
+	if assert.True(t, ok, "Expected PrometheusCollector") {
+		collector.JobSkipped("sync")
+	}
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	var names []string
+	for _, family := range families {
+		names = append(names, family.GetName())
+	}
+	assert.Contains(t, names, "app_job_skipped_total")
+}
+
+func TestServiceProviderRegisterWithTracing(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.Tracing = TracingConfig{Enabled: true, TracerProvider: "tracer"}
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	var registered Manager
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockContainer.EXPECT().Make("tracer").Return(tracerProvider, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager")).Run(func(abstract string, instance interface{}) {
+		registered = instance.(Manager)
+	})
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	_, span := registered.(*manager).telemetry.tracer().Start(context.Background(), "test")
+	span.End()
+	assert.Len(t, exporter.GetSpans(), 1)
+}
+
+func TestServiceProviderRegisterSchedulesConfiguredJobs(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	disabled := false
+	cfg := DefaultConfig()
+	cfg.Jobs = []JobConfig{
+		{Name: "sync", Interval: "5m", Tags: []string{"orders"}},
+		{Name: "cleanup", Cron: "0 3 * * *", Enabled: &disabled},
+	}
+
+	var registered Manager
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager")).Run(func(abstract string, instance interface{}) {
+		registered = instance.(Manager)
+	})
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	jobs := registered.GetScheduler().Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "sync", jobs[0].GetName())
+		assert.Equal(t, []string{"orders"}, jobs[0].Tags())
+	}
+}
+
+func TestServiceProviderRegisterWatchesJobs(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.WatchJobs = true
+	cfg.Jobs = []JobConfig{{Name: "sync", Interval: "5m"}}
+
+	var registered Manager
+	var onChange func(fsnotify.Event)
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockConfig.EXPECT().OnConfigChange(mock.Anything).Run(func(run func(in fsnotify.Event)) {
+		onChange = run
+	}).Return()
+	mockConfig.EXPECT().WatchConfig().Return()
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager")).Run(func(abstract string, instance interface{}) {
+		registered = instance.(Manager)
+	})
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	if !assert.NotNil(t, onChange) {
+		return
+	}
+
+	// File cấu hình thay đổi: sync bị xóa, cleanup được thêm
+	cfg.Jobs = []JobConfig{{Name: "cleanup", Cron: "0 3 * * *"}}
+	onChange(fsnotify.Event{Name: "config/app.yaml", Op: fsnotify.Write})
+
+	jobs := registered.GetScheduler().Jobs()
+	if assert.Len(t, jobs, 1) {
+		assert.Equal(t, "cleanup", jobs[0].GetName())
+	}
+}
+
+func TestServiceProviderBootPanicsOnMissingJobHandler(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+
+	manager := NewScheduler()
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("scheduler").Return(manager, nil)
+
+	provider := &ServiceProvider{jobs: []JobConfig{{Name: "sync", Interval: "5m"}}}
+
+	assert.PanicsWithValue(t, "scheduler: handler sync for job sync is not registered", func() {
+		provider.Boot(mockApp)
+	})
+}
+
 func TestServiceProviderBoot(t *testing.T) {
 	// Tạo mock objects
 	mockApp := diMocks.NewMockApplication(t)
@@ -241,10 +581,175 @@ func TestServiceProviderRequires(t *testing.T) {
 	provider := NewServiceProvider()
 	requires := provider.Requires()
 
+	// Cấu hình chưa được load: khai báo redis của backend mặc định
 	expectedRequires := []string{"config", "redis"}
 	assert.Equal(t, expectedRequires, requires)
 }
 
+func TestServiceProviderRequiresChosenBackend(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	mockRedis := redisMocks.NewMockManager(t)
+	mockRedis.EXPECT().Client().Return(client, nil)
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Make("redis").Return(mockRedis, nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager"))
+
+	provider := NewServiceProvider()
+
+	// Dependency của backend chỉ được biết sau khi Register load cấu hình, trước đó redis của
+	// backend mặc định được khai báo
+	assert.Equal(t, []string{"config", "redis"}, provider.Requires())
+	provider.Register(mockApp)
+	assert.Equal(t, []string{"config", "redis"}, provider.Requires())
+
+	// Register lại với cấu hình không dùng Redis khai báo lại các dependency
+	cfg.DistributedLock.Enabled = false
+	provider.Register(mockApp)
+	assert.Equal(t, []string{"config"}, provider.Requires())
+}
+
+func TestServiceProviderRegisterWithHistory(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	mockRedis := redisMocks.NewMockManager(t)
+	mockRedis.EXPECT().Client().Return(client, nil)
+
+	cfg := DefaultConfig()
+	cfg.DistributedLock.Enabled = true
+	cfg.History = HistoryConfig{Enabled: true, Driver: HistoryDriverRedis, KeyPrefix: "app_history:"}
+
+	var registered Manager
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Make("redis").Return(mockRedis, nil)
+	mockContainer.EXPECT().Instance("scheduler", mock.AnythingOfType("*scheduler.manager")).Run(func(abstract string, instance interface{}) {
+		registered = instance.(Manager)
+	})
+
+	provider := NewServiceProvider()
+	provider.Register(mockApp)
+
+	// Locker và lịch sử cùng dùng redis, dependency chỉ được khai báo một lần
+	assert.Equal(t, []string{"config", "redis"}, provider.Requires())
+
+	_, ok := registered.(*manager).history.(*redisHistoryStore)
+	assert.True(t, ok, "Expected Redis history store")
+	runs, err := registered.History(context.Background(), "report", 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, runs)
+}
+
+func TestServiceProviderRegisterWithInvalidHistoryDatabase(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+	mockConfig := configMocks.NewMockManager(t)
+
+	cfg := DefaultConfig()
+	cfg.History = HistoryConfig{Enabled: true, Driver: HistoryDriverSQLite, Database: "history_db"}
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("config").Return(mockConfig, nil)
+	mockConfig.EXPECT().UnmarshalKey("scheduler", mock.AnythingOfType("*scheduler.Config")).Run(func(key string, target interface{}) {
+		if config, ok := target.(*Config); ok {
+			*config = cfg
+		}
+	}).Return(nil)
+	mockContainer.EXPECT().Make("history_db").Return("not a db", nil)
+
+	provider := NewServiceProvider()
+	assert.PanicsWithValue(t, "scheduler: failed to create history store: database service history_db is not a valid *sql.DB", func() {
+		provider.Register(mockApp)
+	})
+}
+
+func TestServiceProviderTerminate(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+
+	scheduler := NewScheduler()
+	scheduler.StartAsync()
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("scheduler").Return(scheduler, nil)
+
+	provider := NewServiceProvider()
+	provider.shutdownTimeout = time.Second
+
+	assert.NoError(t, provider.Terminate(mockApp))
+	assert.False(t, scheduler.IsRunning())
+}
+
+// shutdownApp là application giả lập có hỗ trợ ShutdownRegistrar.
+type shutdownApp struct {
+	*diMocks.MockApplication
+	hooks []func(ctx context.Context) error
+}
+
+func (a *shutdownApp) OnShutdown(fn func(ctx context.Context) error) {
+	a.hooks = append(a.hooks, fn)
+}
+
+func TestServiceProviderBootRegistersShutdown(t *testing.T) {
+	mockContainer := diMocks.NewMockContainer(t)
+	app := &shutdownApp{MockApplication: diMocks.NewMockApplication(t)}
+
+	scheduler := NewScheduler()
+	scheduler.StartAsync()
+
+	app.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("scheduler").Return(scheduler, nil)
+
+	provider := NewServiceProvider()
+	provider.shutdownTimeout = time.Second
+	provider.Boot(app)
+
+	if len(app.hooks) != 1 {
+		t.Fatalf("Expected 1 shutdown hook, got %d", len(app.hooks))
+	}
+	assert.NoError(t, app.hooks[0](context.Background()))
+	assert.False(t, scheduler.IsRunning())
+}
+
+func TestServiceProviderTerminateInvalidManager(t *testing.T) {
+	mockApp := diMocks.NewMockApplication(t)
+	mockContainer := diMocks.NewMockContainer(t)
+
+	mockApp.EXPECT().Container().Return(mockContainer)
+	mockContainer.EXPECT().Make("scheduler").Return("not a scheduler", nil)
+
+	provider := NewServiceProvider()
+
+	assert.ErrorIs(t, provider.Terminate(mockApp), ErrInvalidManager)
+}
+
 func TestServiceProviderProviders(t *testing.T) {
 	provider := NewServiceProvider()
 
@@ -0,0 +1,100 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultHistoryKeyPrefix là tiền tố mặc định của các key lịch sử trong Redis.
+const DefaultHistoryKeyPrefix = "scheduler_history:"
+
+// redisHistoryStore triển khai HistoryStore sử dụng Redis làm backend.
+//
+// Lịch sử của mỗi job là một list với lần chạy mới nhất ở đầu, được cắt còn tối đa
+// maxEntries phần tử sau mỗi lần ghi.
+type redisHistoryStore struct {
+	client     redis.UniversalClient
+	keyPrefix  string
+	maxEntries int
+}
+
+// NewRedisHistoryStore tạo HistoryStore lưu lịch sử trong Redis, giữ tối đa maxEntries lần
+// chạy gần nhất cho mỗi job (0 hoặc âm sẽ dùng DefaultHistoryMaxEntries). keyPrefix trống
+// sẽ dùng DefaultHistoryKeyPrefix.
+//
+// Example:
+//
+//	store, err := scheduler.NewRedisHistoryStore(redisClient, "", 500)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithHistory(store)
+func NewRedisHistoryStore(client redis.UniversalClient, keyPrefix string, maxEntries int) (HistoryStore, error) {
+	if isNilClient(client) {
+		return nil, ErrRedisClientNil
+	}
+	if keyPrefix == "" {
+		keyPrefix = DefaultHistoryKeyPrefix
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultHistoryMaxEntries
+	}
+
+	// Kiểm tra kết nối đến Redis
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, ErrFailedToConnectToRedis
+	}
+
+	return &redisHistoryStore{
+		client:     client,
+		keyPrefix:  keyPrefix,
+		maxEntries: maxEntries,
+	}, nil
+}
+
+// Record triển khai HistoryStore.
+func (s *redisHistoryStore) Record(ctx context.Context, run JobRun) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+
+	key := s.keyPrefix + run.JobName
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, key, data)
+		pipe.LTrim(ctx, key, 0, int64(s.maxEntries-1))
+		return nil
+	})
+	return err
+}
+
+// List triển khai HistoryStore.
+func (s *redisHistoryStore) List(ctx context.Context, job string, limit, offset int) ([]JobRun, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		return []JobRun{}, nil
+	}
+
+	values, err := s.client.LRange(ctx, s.keyPrefix+job, int64(offset), int64(offset+limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]JobRun, 0, len(values))
+	for _, value := range values {
+		var run JobRun
+		if err := json.Unmarshal([]byte(value), &run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestNewRedisHistoryStoreValidation(t *testing.T) {
+	if _, err := NewRedisHistoryStore(nil, "", 0); err != ErrRedisClientNil {
+		t.Errorf("Expected ErrRedisClientNil, got %v", err)
+	}
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	server.Close()
+
+	if _, err := NewRedisHistoryStore(client, "", 0); err != ErrFailedToConnectToRedis {
+		t.Errorf("Expected ErrFailedToConnectToRedis, got %v", err)
+	}
+}
+
+func TestRedisHistoryStoreRecordAndList(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	store, err := NewRedisHistoryStore(client, "app_history:", 3)
+	if err != nil {
+		t.Fatalf("Failed to create history store: %v", err)
+	}
+
+	recordTestRuns(t, store, "report", 5)
+
+	runs, err := store.List(context.Background(), "report", 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list runs: %v", err)
+	}
+	if got := attempts(runs); got != "[5 4 3]" {
+		t.Errorf("Expected the 3 newest runs, got %s", got)
+	}
+	if runs[0].InstanceID != "node-1" || len(runs[0].Tags) != 2 || runs[0].StartedAt.IsZero() {
+		t.Errorf("Unexpected run: %+v", runs[0])
+	}
+
+	runs, _ = store.List(context.Background(), "report", 1, 1)
+	if got := attempts(runs); got != "[4]" {
+		t.Errorf("Expected paginated runs, got %s", got)
+	}
+
+	if n, _ := client.LLen(context.Background(), "app_history:report").Result(); n != 3 {
+		t.Errorf("Expected list to be trimmed to 3 entries, got %d", n)
+	}
+}
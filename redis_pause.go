@@ -0,0 +1,85 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultPauseKeyPrefix là tiền tố mặc định của các key trạng thái tạm dừng trong Redis.
+const DefaultPauseKeyPrefix = "scheduler_pause:"
+
+// redisPauseStore triển khai PauseStore sử dụng Redis làm backend.
+//
+// Các job và tag bị tạm dừng được lưu trong hai set "<prefix>jobs" và "<prefix>tags".
+type redisPauseStore struct {
+	client    redis.UniversalClient
+	keyPrefix string
+}
+
+// NewRedisPauseStore tạo PauseStore lưu trạng thái tạm dừng trong Redis, dùng chung giữa các
+// instance. keyPrefix trống sẽ dùng DefaultPauseKeyPrefix.
+//
+// Example:
+//
+//	store, err := scheduler.NewRedisPauseStore(redisClient, "")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithPauseStore(store)
+func NewRedisPauseStore(client redis.UniversalClient, keyPrefix string) (PauseStore, error) {
+	if isNilClient(client) {
+		return nil, ErrRedisClientNil
+	}
+	if keyPrefix == "" {
+		keyPrefix = DefaultPauseKeyPrefix
+	}
+
+	// Kiểm tra kết nối đến Redis
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		return nil, ErrFailedToConnectToRedis
+	}
+
+	return &redisPauseStore{client: client, keyPrefix: keyPrefix}, nil
+}
+
+// SetJobPaused triển khai PauseStore.
+func (s *redisPauseStore) SetJobPaused(ctx context.Context, name string, paused bool) error {
+	return s.set(ctx, s.keyPrefix+"jobs", name, paused)
+}
+
+// SetTagPaused triển khai PauseStore.
+func (s *redisPauseStore) SetTagPaused(ctx context.Context, tag string, paused bool) error {
+	return s.set(ctx, s.keyPrefix+"tags", tag, paused)
+}
+
+// Load triển khai PauseStore.
+func (s *redisPauseStore) Load(ctx context.Context) (PauseState, error) {
+	var jobs, tags *redis.StringSliceCmd
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		jobs = pipe.SMembers(ctx, s.keyPrefix+"jobs")
+		tags = pipe.SMembers(ctx, s.keyPrefix+"tags")
+		return nil
+	})
+	if err != nil {
+		return PauseState{}, err
+	}
+
+	state := PauseState{Jobs: jobs.Val(), Tags: tags.Val()}
+	sort.Strings(state.Jobs)
+	sort.Strings(state.Tags)
+	return state, nil
+}
+
+// set thêm member vào set key nếu paused là true, ngược lại xóa member khỏi set.
+func (s *redisPauseStore) set(ctx context.Context, key, member string, paused bool) error {
+	if paused {
+		return s.client.SAdd(ctx, key, member).Err()
+	}
+	return s.client.SRem(ctx, key, member).Err()
+}
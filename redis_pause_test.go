@@ -0,0 +1,43 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestNewRedisPauseStoreValidation(t *testing.T) {
+	if _, err := NewRedisPauseStore(nil, ""); err != ErrRedisClientNil {
+		t.Errorf("Expected ErrRedisClientNil, got %v", err)
+	}
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	server.Close()
+
+	if _, err := NewRedisPauseStore(client, ""); err != ErrFailedToConnectToRedis {
+		t.Errorf("Expected ErrFailedToConnectToRedis, got %v", err)
+	}
+}
+
+func TestRedisPauseStore(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	store, err := NewRedisPauseStore(client, "app_pause:")
+	if err != nil {
+		t.Fatalf("Failed to create pause store: %v", err)
+	}
+	testPauseStore(t, store)
+
+	members, err := server.SMembers("app_pause:jobs")
+	if err != nil {
+		t.Fatalf("Failed to read paused jobs: %v", err)
+	}
+	if len(members) != 1 || members[0] != "cleanup" {
+		t.Errorf("Expected paused jobs [cleanup], got %v", members)
+	}
+}
@@ -0,0 +1,204 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.fork.vn/di"
+)
+
+// JobHandler là handler của job dưới dạng service, thường được đăng ký làm DI binding.
+type JobHandler interface {
+	// Handle thực thi job với context của lần chạy.
+	Handle(ctx context.Context) error
+}
+
+// JobRegistry lưu trữ các handler của job theo tên.
+//
+// Mỗi Manager có một JobRegistry riêng (Manager.Registry()). Handler có thể là một JobFunc
+// (Register) hoặc một DI binding được resolve từ container tại mỗi lần chạy (RegisterBinding).
+// Các job khai báo trong cấu hình (Config.Jobs) và DoHandler tham chiếu tới handler bằng tên;
+// handler được tra cứu tại thời điểm job chạy, vì vậy handler có thể được đăng ký sau khi job
+// đã được lên lịch.
+type JobRegistry struct {
+	mu        sync.RWMutex
+	handlers  map[string]JobFunc
+	bindings  map[string]string // Tên handler -> key của binding trong DI container
+	container di.Container
+}
+
+// NewJobRegistry tạo một JobRegistry rỗng.
+func NewJobRegistry() *JobRegistry {
+	return &JobRegistry{
+		handlers: make(map[string]JobFunc),
+		bindings: make(map[string]string),
+	}
+}
+
+// SetContainer thiết lập DI container dùng để resolve các handler đăng ký qua RegisterBinding.
+// ServiceProvider gọi SetContainer với container của ứng dụng khi Register.
+func (r *JobRegistry) SetContainer(container di.Container) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.container = container
+}
+
+// Register đăng ký handler với tên name.
+//
+// Example:
+//
+//	err := manager.Registry().Register("sync-orders", func(ctx context.Context) error {
+//		return syncOrders(ctx)
+//	})
+func (r *JobRegistry) Register(name string, handler JobFunc) error {
+	if name == "" {
+		return ErrJobHandlerNameRequired
+	}
+	if handler == nil {
+		return ErrJobHandlerNil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.exists(name) {
+		return ErrJobHandlerExists
+	}
+	r.handlers[name] = handler
+	return nil
+}
+
+// RegisterBinding đăng ký handler với tên name được resolve từ DI container theo key abstract
+// tại mỗi lần chạy job.
+//
+// Instance được resolve phải là JobFunc, func(context.Context) error hoặc JobHandler. Việc
+// resolve muộn cho phép binding được khai báo bởi service provider khác và lấy các
+// dependency mới nhất (ví dụ binding không phải singleton).
+//
+// Example:
+//
+//	container.Bind("orders.sync_job", func(c di.Container) interface{} {
+//		return orders.NewSyncJob(c.MustMake("db").(*sql.DB))
+//	})
+//	err := manager.Registry().RegisterBinding("orders.sync", "orders.sync_job")
+func (r *JobRegistry) RegisterBinding(name string, abstract string) error {
+	if name == "" {
+		return ErrJobHandlerNameRequired
+	}
+	if abstract == "" {
+		return ErrJobHandlerNil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.exists(name) {
+		return ErrJobHandlerExists
+	}
+	r.bindings[name] = abstract
+	return nil
+}
+
+// exists kiểm tra tên handler đã được đăng ký chưa. Caller phải giữ r.mu.
+func (r *JobRegistry) exists(name string) bool {
+	_, isHandler := r.handlers[name]
+	_, isBinding := r.bindings[name]
+	return isHandler || isBinding
+}
+
+// Handler trả về handler đã đăng ký với tên name.
+//
+// Với handler đăng ký qua RegisterBinding, JobFunc trả về resolve binding từ DI container
+// mỗi khi được gọi.
+func (r *JobRegistry) Handler(name string) (JobFunc, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if handler, ok := r.handlers[name]; ok {
+		return handler, true
+	}
+	if abstract, ok := r.bindings[name]; ok {
+		return func(ctx context.Context) error {
+			handler, err := r.resolve(abstract)
+			if err != nil {
+				return err
+			}
+			return handler(ctx)
+		}, true
+	}
+	return nil, false
+}
+
+// resolve lấy handler từ DI container theo key abstract.
+func (r *JobRegistry) resolve(abstract string) (JobFunc, error) {
+	r.mu.RLock()
+	container := r.container
+	r.mu.RUnlock()
+
+	if container == nil {
+		return nil, ErrJobRegistryNoContainer
+	}
+
+	instance, err := container.Make(abstract)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidJobHandler, abstract, err)
+	}
+
+	switch handler := instance.(type) {
+	case JobFunc:
+		return handler, nil
+	case func(context.Context) error:
+		return handler, nil
+	case JobHandler:
+		return handler.Handle, nil
+	default:
+		return nil, fmt.Errorf("%w: %s is %T", ErrInvalidJobHandler, abstract, instance)
+	}
+}
+
+// Has kiểm tra handler với tên name đã được đăng ký chưa.
+func (r *JobRegistry) Has(name string) bool {
+	_, ok := r.Handler(name)
+	return ok
+}
+
+// Names trả về tên các handler đã đăng ký, theo thứ tự alphabet.
+func (r *JobRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.handlers)+len(r.bindings))
+	for name := range r.handlers {
+		names = append(names, name)
+	}
+	for name := range r.bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Error constants cho JobRegistry
+var (
+	// ErrJobHandlerNameRequired được trả về khi đăng ký handler với tên trống.
+	ErrJobHandlerNameRequired = errors.New("scheduler: job handler name is required")
+
+	// ErrJobHandlerNil được trả về khi đăng ký handler nil.
+	ErrJobHandlerNil = errors.New("scheduler: job handler is nil")
+
+	// ErrJobHandlerExists được trả về khi tên handler đã được đăng ký.
+	ErrJobHandlerExists = errors.New("scheduler: job handler already registered")
+
+	// ErrJobHandlerNotFound được trả về khi job tham chiếu tới handler chưa được đăng ký.
+	ErrJobHandlerNotFound = errors.New("scheduler: job handler not found")
+
+	// ErrJobRegistryNoContainer được trả về khi resolve handler từ binding nhưng JobRegistry
+	// chưa có DI container.
+	ErrJobRegistryNoContainer = errors.New("scheduler: job registry has no DI container")
+
+	// ErrInvalidJobHandler được trả về khi binding không resolve được thành handler hợp lệ.
+	ErrInvalidJobHandler = errors.New("scheduler: invalid job handler binding")
+)
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	diMocks "go.fork.vn/di/mocks"
+)
+
+func TestJobRegistryRegister(t *testing.T) {
+	registry := NewJobRegistry()
+	handler := func(ctx context.Context) error { return nil }
+
+	assert.NoError(t, registry.Register("sync", handler))
+	assert.NoError(t, registry.Register("cleanup", handler))
+
+	assert.True(t, registry.Has("sync"))
+	assert.False(t, registry.Has("missing"))
+	assert.Equal(t, []string{"cleanup", "sync"}, registry.Names())
+
+	got, ok := registry.Handler("sync")
+	assert.True(t, ok)
+	assert.NotNil(t, got)
+}
+
+func TestJobRegistryRegisterErrors(t *testing.T) {
+	registry := NewJobRegistry()
+	handler := func(ctx context.Context) error { return nil }
+
+	assert.ErrorIs(t, registry.Register("", handler), ErrJobHandlerNameRequired)
+	assert.ErrorIs(t, registry.Register("sync", nil), ErrJobHandlerNil)
+
+	assert.NoError(t, registry.Register("sync", handler))
+	assert.ErrorIs(t, registry.Register("sync", handler), ErrJobHandlerExists)
+}
+
+// syncJob là JobHandler dùng để kiểm tra RegisterBinding.
+type syncJob struct {
+	ran chan struct{}
+}
+
+func (j *syncJob) Handle(ctx context.Context) error {
+	j.ran <- struct{}{}
+	return nil
+}
+
+func TestJobRegistryRegisterBinding(t *testing.T) {
+	registry := NewJobRegistry()
+	container := diMocks.NewMockContainer(t)
+	job := &syncJob{ran: make(chan struct{}, 1)}
+
+	assert.NoError(t, registry.RegisterBinding("orders.sync", "orders.sync_job"))
+	assert.ErrorIs(t, registry.RegisterBinding("orders.sync", "other"), ErrJobHandlerExists)
+	assert.ErrorIs(t, registry.Register("orders.sync", func(ctx context.Context) error { return nil }), ErrJobHandlerExists)
+	assert.True(t, registry.Has("orders.sync"))
+	assert.Equal(t, []string{"orders.sync"}, registry.Names())
+
+	handler, ok := registry.Handler("orders.sync")
+	assert.True(t, ok)
+
+	// Chưa có container
+	assert.ErrorIs(t, handler(context.Background()), ErrJobRegistryNoContainer)
+
+	registry.SetContainer(container)
+	container.EXPECT().Make("orders.sync_job").Return(job, nil).Once()
+
+	assert.NoError(t, handler(context.Background()))
+	assert.Len(t, job.ran, 1)
+}
+
+func TestJobRegistryRegisterBindingResolvesFunctions(t *testing.T) {
+	registry := NewJobRegistry()
+	container := diMocks.NewMockContainer(t)
+	registry.SetContainer(container)
+
+	called := false
+	container.EXPECT().Make("func_job").Return(func(ctx context.Context) error {
+		called = true
+		return nil
+	}, nil)
+
+	assert.NoError(t, registry.RegisterBinding("func", "func_job"))
+
+	handler, _ := registry.Handler("func")
+	assert.NoError(t, handler(context.Background()))
+	assert.True(t, called)
+}
+
+func TestJobRegistryRegisterBindingInvalidHandler(t *testing.T) {
+	registry := NewJobRegistry()
+	container := diMocks.NewMockContainer(t)
+	registry.SetContainer(container)
+
+	container.EXPECT().Make("invalid").Return("not a handler", nil)
+	container.EXPECT().Make("missing").Return(nil, assert.AnError)
+
+	assert.NoError(t, registry.RegisterBinding("invalid", "invalid"))
+	assert.NoError(t, registry.RegisterBinding("missing", "missing"))
+	assert.ErrorIs(t, registry.RegisterBinding("", "missing"), ErrJobHandlerNameRequired)
+
+	handler, _ := registry.Handler("invalid")
+	assert.ErrorIs(t, handler(context.Background()), ErrInvalidJobHandler)
+
+	handler, _ = registry.Handler("missing")
+	assert.ErrorIs(t, handler(context.Background()), ErrInvalidJobHandler)
+}
@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy xác định cách một lần chạy job thất bại được thử lại.
+//
+// Các lần thử lại diễn ra trong cùng một lần chạy của gocron, vì vậy khóa phân tán của job
+// được giữ (và gia hạn) trong suốt chuỗi thử lại. Việc thử lại dừng khi context của job bị hủy
+// (scheduler dừng hoặc khóa bị mất).
+type RetryPolicy struct {
+	// MaxAttempts là tổng số lần chạy tối đa, tính cả lần đầu; 0 hoặc 1 nghĩa là không thử lại
+	MaxAttempts int
+
+	// InitialDelay là thời gian chờ trước lần thử lại đầu tiên
+	InitialDelay time.Duration
+
+	// Multiplier là hệ số nhân thời gian chờ sau mỗi lần thử lại, 0 tương đương 2
+	Multiplier float64
+
+	// MaxDelay giới hạn thời gian chờ giữa các lần thử lại, 0 nghĩa là không giới hạn
+	MaxDelay time.Duration
+
+	// Jitter là tỷ lệ ngẫu nhiên (0 đến 1) cộng hoặc trừ vào thời gian chờ
+	Jitter float64
+
+	// Retryable xác định lỗi có được thử lại không, nil nghĩa là mọi lỗi đều được thử lại
+	Retryable func(err error) bool
+}
+
+// Validate kiểm tra tính hợp lệ của RetryPolicy.
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 0 || p.InitialDelay < 0 || p.MaxDelay < 0 {
+		return ErrInvalidRetryPolicy
+	}
+	if p.Multiplier != 0 && p.Multiplier < 1 {
+		return ErrInvalidRetryPolicy
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return ErrInvalidRetryPolicy
+	}
+	return nil
+}
+
+// shouldRetry kiểm tra lần chạy thứ attempt thất bại với err có được thử lại không.
+func (p RetryPolicy) shouldRetry(attempt int, err error) bool {
+	if attempt >= p.MaxAttempts {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// delay trả về thời gian chờ sau lần chạy thứ attempt thất bại.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	if p.InitialDelay <= 0 {
+		return 0
+	}
+
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+
+	d := float64(p.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxDelay > 0 && d > float64(p.MaxDelay) {
+		d = float64(p.MaxDelay)
+	}
+	// Thời gian chờ tăng theo cấp số nhân có thể vượt quá time.Duration khi MaxDelay là 0
+	d = min(d, float64(math.MaxInt64))
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	if d >= float64(math.MaxInt64) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(d)
+}
+
+// RetryConfig là cấu hình RetryPolicy cho job khai báo trong cấu hình.
+type RetryConfig struct {
+	// MaxAttempts là tổng số lần chạy tối đa, tính cả lần đầu
+	MaxAttempts int `mapstructure:"max_attempts" yaml:"max_attempts"`
+
+	// InitialDelay là thời gian chờ trước lần thử lại đầu tiên (milliseconds)
+	InitialDelay int `mapstructure:"initial_delay" yaml:"initial_delay"`
+
+	// Multiplier là hệ số nhân thời gian chờ sau mỗi lần thử lại, 0 tương đương 2
+	Multiplier float64 `mapstructure:"multiplier" yaml:"multiplier"`
+
+	// MaxDelay giới hạn thời gian chờ giữa các lần thử lại (milliseconds), 0 nghĩa là không giới hạn
+	MaxDelay int `mapstructure:"max_delay" yaml:"max_delay"`
+
+	// Jitter là tỷ lệ ngẫu nhiên (0 đến 1) cộng hoặc trừ vào thời gian chờ
+	Jitter float64 `mapstructure:"jitter" yaml:"jitter"`
+}
+
+// ToRetryPolicy chuyển đổi RetryConfig thành RetryPolicy, mọi lỗi đều được thử lại.
+func (c RetryConfig) ToRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  c.MaxAttempts,
+		InitialDelay: time.Duration(c.InitialDelay) * time.Millisecond,
+		Multiplier:   c.Multiplier,
+		MaxDelay:     time.Duration(c.MaxDelay) * time.Millisecond,
+		Jitter:       c.Jitter,
+	}
+}
+
+// Error constants cho retry
+var (
+	// ErrInvalidRetryPolicy được trả về khi RetryPolicy có giá trị âm, Multiplier nhỏ hơn 1
+	// hoặc Jitter nằm ngoài khoảng 0 đến 1.
+	ErrInvalidRetryPolicy = errors.New("scheduler: invalid retry policy")
+)
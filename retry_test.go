@@ -0,0 +1,203 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyValidate(t *testing.T) {
+	assert.NoError(t, RetryPolicy{}.Validate())
+	assert.NoError(t, RetryPolicy{MaxAttempts: 3, InitialDelay: time.Second, Multiplier: 1.5, Jitter: 0.2}.Validate())
+
+	invalid := []RetryPolicy{
+		{MaxAttempts: -1},
+		{InitialDelay: -time.Second},
+		{MaxDelay: -time.Second},
+		{Multiplier: 0.5},
+		{Jitter: 1.5},
+	}
+	for _, policy := range invalid {
+		assert.ErrorIs(t, policy.Validate(), ErrInvalidRetryPolicy, "%+v", policy)
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.delay(1))
+	assert.Equal(t, 200*time.Millisecond, policy.delay(2))
+	assert.Equal(t, 400*time.Millisecond, policy.delay(3))
+	assert.Equal(t, time.Second, policy.delay(5))
+
+	policy.Multiplier = 3
+	assert.Equal(t, 300*time.Millisecond, policy.delay(2))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := policy.delay(1)
+		assert.GreaterOrEqual(t, d, 50*time.Millisecond)
+		assert.LessOrEqual(t, d, 150*time.Millisecond)
+	}
+}
+
+func TestRetryPolicyDelayWithoutMaxDelay(t *testing.T) {
+	policy := RetryPolicy{InitialDelay: time.Second}
+
+	// Không có MaxDelay, thời gian chờ bị giới hạn thay vì tràn số
+	for _, attempt := range []int{64, 100, 2000} {
+		assert.Equal(t, time.Duration(math.MaxInt64), policy.delay(attempt), "attempt %d", attempt)
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := policy.delay(2000)
+		assert.GreaterOrEqual(t, d, time.Duration(math.MaxInt64/2))
+		assert.LessOrEqual(t, d, time.Duration(math.MaxInt64))
+	}
+
+	policy = RetryPolicy{MaxAttempts: 2000}
+	assert.Equal(t, time.Duration(0), policy.delay(2000))
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	errPermanent := errors.New("permanent")
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		Retryable:   func(err error) bool { return !errors.Is(err, errPermanent) },
+	}
+
+	assert.True(t, policy.shouldRetry(1, assert.AnError))
+	assert.True(t, policy.shouldRetry(2, assert.AnError))
+	assert.False(t, policy.shouldRetry(3, assert.AnError))
+	assert.False(t, policy.shouldRetry(1, errPermanent))
+	assert.False(t, RetryPolicy{}.shouldRetry(1, assert.AnError))
+}
+
+func TestRetryConfigToRetryPolicy(t *testing.T) {
+	policy := RetryConfig{MaxAttempts: 5, InitialDelay: 200, Multiplier: 1.5, MaxDelay: 5000, Jitter: 0.1}.ToRetryPolicy()
+
+	assert.Equal(t, 5, policy.MaxAttempts)
+	assert.Equal(t, 200*time.Millisecond, policy.InitialDelay)
+	assert.Equal(t, 1.5, policy.Multiplier)
+	assert.Equal(t, 5*time.Second, policy.MaxDelay)
+	assert.Equal(t, 0.1, policy.Jitter)
+	assert.Nil(t, policy.Retryable)
+}
+
+func TestDoContextRetriesFailedRuns(t *testing.T) {
+	locker := &recordingLocker{lock: &notifyingLock{lost: make(chan struct{})}}
+	scheduler := NewScheduler().WithDistributedLocker(locker)
+
+	var mu sync.Mutex
+	var events []Event
+	done := make(chan struct{})
+	scheduler.OnEvent(func(event Event) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event)
+		if event.Type == EventJobSucceeded {
+			close(done)
+		}
+	})
+
+	var attempts []int
+	_, err := scheduler.Every(1).Hours().Name("flaky").
+		Retry(RetryPolicy{MaxAttempts: 3, InitialDelay: 10 * time.Millisecond}).
+		DoContext(func(ctx context.Context) error {
+			info, _ := JobInfoFromContext(ctx)
+			attempts = append(attempts, info.Attempt)
+			if info.Attempt < 3 {
+				return assert.AnError
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to succeed after retries")
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, attempts)
+
+	mu.Lock()
+	var types []EventType
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+	mu.Unlock()
+	assert.Equal(t, []EventType{
+		EventJobStarted, EventJobFailed, EventJobRetrying,
+		EventJobStarted, EventJobFailed, EventJobRetrying,
+		EventJobStarted, EventJobSucceeded,
+	}, types)
+
+	// Khóa chỉ được lấy một lần cho cả chuỗi thử lại
+	locker.mu.Lock()
+	assert.Equal(t, []string{"flaky"}, locker.keys)
+	locker.mu.Unlock()
+}
+
+func TestDoContextStopsRetryingNonRetryableErrors(t *testing.T) {
+	scheduler := NewScheduler()
+
+	errPermanent := errors.New("permanent")
+	failed := make(chan Event, 5)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobFailed {
+			failed <- event
+		}
+	})
+
+	_, err := scheduler.Every(1).Hours().Name("permanent").
+		Retry(RetryPolicy{
+			MaxAttempts: 3,
+			Retryable:   func(err error) bool { return !errors.Is(err, errPermanent) },
+		}).
+		DoContext(func(ctx context.Context) error { return errPermanent })
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case event := <-failed:
+		assert.Equal(t, 1, event.Attempt)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to fail")
+	}
+
+	select {
+	case event := <-failed:
+		t.Errorf("Expected no retry, got attempt %d", event.Attempt)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRetryWithInvalidPolicy(t *testing.T) {
+	scheduler := NewScheduler()
+
+	_, err := scheduler.Every(1).Hours().Retry(RetryPolicy{MaxAttempts: -1}).DoContext(func(ctx context.Context) error { return nil })
+	assert.ErrorIs(t, err, ErrInvalidRetryPolicy)
+	assert.Empty(t, scheduler.GetScheduler().Jobs())
+
+	// Fluent chain được đặt lại sau lỗi
+	job, err := scheduler.Every(2).Hours().Name("next").DoContext(func(ctx context.Context) error { return nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "next", job.GetName())
+	assert.Len(t, scheduler.GetScheduler().Jobs(), 1)
+}
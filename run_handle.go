@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+)
+
+// RunResult là kết quả của một lần chạy được kích hoạt qua RunNow hoặc RunByTag.
+type RunResult struct {
+	// Name là tên của job
+	Name string
+
+	// Err là lỗi của lần chạy (sau khi áp dụng retry, timeout và middleware), hoặc lỗi bọc
+	// ErrRunSkipped nếu lần chạy bị bỏ qua
+	Err error
+
+	// Skipped cho biết lần chạy bị bỏ qua do instance không phải leader hoặc không lấy được khóa
+	Skipped bool
+}
+
+// RunHandle theo dõi các lần chạy được kích hoạt qua RunNow hoặc RunByTag.
+//
+// Mỗi job khớp với tên hoặc tag có một RunResult, theo thứ tự các job trong scheduler.
+// RunHandle an toàn khi dùng từ nhiều goroutine.
+type RunHandle struct {
+	done    chan struct{}
+	results []RunResult // Mỗi phần tử chỉ được ghi bởi goroutine của lần chạy tương ứng
+}
+
+// Done trả về channel được đóng khi tất cả các lần chạy kết thúc.
+func (h *RunHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait chờ tới khi tất cả các lần chạy kết thúc và trả về lỗi của chúng (xem Err),
+// hoặc ctx.Err() nếu ctx bị hủy trước đó. Hủy ctx không dừng các lần chạy.
+func (h *RunHandle) Wait(ctx context.Context) error {
+	select {
+	case <-h.done:
+		return h.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Results trả về kết quả của các lần chạy, nil nếu các lần chạy chưa kết thúc.
+func (h *RunHandle) Results() []RunResult {
+	select {
+	case <-h.done:
+		return append([]RunResult(nil), h.results...)
+	default:
+		return nil
+	}
+}
+
+// Err trả về lỗi của các lần chạy được gộp bằng errors.Join, nil nếu mọi lần chạy thành công
+// hoặc các lần chạy chưa kết thúc.
+func (h *RunHandle) Err() error {
+	var errs []error
+	for _, result := range h.Results() {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
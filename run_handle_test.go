@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunHandle(t *testing.T) {
+	handle := &RunHandle{
+		done: make(chan struct{}),
+		results: []RunResult{
+			{Name: "invoice"},
+			{Name: "charge", Err: errors.New("card declined")},
+			{Name: "refund", Err: ErrRunSkipped, Skipped: true},
+		},
+	}
+
+	// Kết quả chưa có khi các lần chạy chưa kết thúc
+	assert.Nil(t, handle.Results())
+	assert.NoError(t, handle.Err())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, handle.Wait(ctx), context.Canceled)
+
+	close(handle.done)
+	err := handle.Wait(context.Background())
+	assert.ErrorIs(t, err, ErrRunSkipped)
+	assert.EqualError(t, err, "card declined\n"+ErrRunSkipped.Error())
+
+	// Results trả về bản sao
+	results := handle.Results()
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	results[0].Name = "changed"
+	assert.Equal(t, "invoice", handle.Results()[0].Name)
+}
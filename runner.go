@@ -0,0 +1,576 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// JobFunc là hàm job nhận context, context bị hủy khi job không còn được phép chạy
+// (scheduler dừng hoặc khóa phân tán bị mất). Lỗi trả về được phát qua EventJobFailed.
+type JobFunc func(ctx context.Context) error
+
+// JobInfo mô tả job đang chạy, được gắn vào context truyền cho JobFunc.
+type JobInfo struct {
+	// Name là tên của job
+	Name string
+
+	// Tags là các tag của job tại thời điểm đăng ký
+	Tags []string
+
+	// Attempt là số thứ tự của lần chạy hiện tại trong chuỗi thử lại, bắt đầu từ 1
+	Attempt int
+}
+
+// jobInfoKey là key của JobInfo trong context.
+type jobInfoKey struct{}
+
+// JobInfoFromContext trả về JobInfo của job đang chạy từ context truyền cho JobFunc.
+func JobInfoFromContext(ctx context.Context) (JobInfo, bool) {
+	info, ok := ctx.Value(jobInfoKey{}).(JobInfo)
+	return info, ok
+}
+
+// jobDefinition lưu thông tin của job được thu thập trong fluent chain.
+type jobDefinition struct {
+	name       string
+	tags       []string
+	schedule   string          // Mô tả lịch chạy, ghi vào span của mỗi lần chạy
+	handler    string          // Tên handler của job khai báo trong cấu hình
+	retry      RetryPolicy     // Chính sách thử lại khi job thất bại
+	timeout    time.Duration   // Thời gian chạy tối đa, 0 nghĩa là dùng timeout mặc định
+	middleware []JobMiddleware // Middleware riêng của job
+	singleton  bool            // Job không chạy đồng thời với chính nó
+	err        error           // Lỗi cấu hình phát sinh trong fluent chain
+}
+
+// lockTracker ghi nhận các khóa phân tán đang được giữ, theo lock key của gocron.
+type lockTracker struct {
+	mu           sync.Mutex
+	locks        map[string]gocron.Lock
+	holders      map[string]int             // Số lần chạy đang dùng chung khóa của mỗi key
+	acquisitions map[string]lockAcquisition // Thời gian lấy các khóa chưa được lần chạy nào ghi nhận vào span
+	acquired     map[string]*trackedLock    // Các khóa vừa lấy được, chưa được lần chạy theo lịch nào nhận
+	unlocked     map[string]bool            // Các key không lấy khóa phân tán
+	unpaused     map[string]bool            // Các key đã được kiểm tra không bị tạm dừng khi lấy khóa, chưa được lần chạy nào sử dụng
+	paused       func(key string) bool      // Kiểm tra job có key đang bị tạm dừng (nếu có)
+	telemetry    *telemetry
+}
+
+// newLockTracker tạo một lockTracker rỗng.
+func newLockTracker() *lockTracker {
+	return &lockTracker{
+		locks:        make(map[string]gocron.Lock),
+		holders:      make(map[string]int),
+		acquisitions: make(map[string]lockAcquisition),
+		acquired:     make(map[string]*trackedLock),
+		unlocked:     make(map[string]bool),
+		unpaused:     make(map[string]bool),
+	}
+}
+
+// exempt đánh dấu key không lấy khóa phân tán (skip = true) hoặc bỏ đánh dấu.
+func (t *lockTracker) exempt(key string, skip bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if skip {
+		t.unlocked[key] = true
+	} else {
+		delete(t.unlocked, key)
+	}
+}
+
+// get trả về khóa đang được giữ cho key, nil nếu không có.
+func (t *lockTracker) get(key string) gocron.Lock {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.locks[key]
+}
+
+// share trả về một tham chiếu tới khóa đang được giữ cho key, nil nếu không có. Khóa gốc chỉ
+// được giải phóng khi mọi tham chiếu (kể cả tham chiếu gocron giải phóng muộn sau lần chạy
+// theo lịch) đã Unlock, vì vậy lần chạy dùng chung khóa luôn được bảo vệ tới khi kết thúc.
+func (t *lockTracker) share(key string) gocron.Lock {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lock, ok := t.locks[key]
+	if !ok {
+		return nil
+	}
+	t.holders[key]++
+	return &trackedLock{Lock: lock, key: key, tracker: t}
+}
+
+// takeAcquisition trả về và xóa thời gian lấy khóa đang được giữ cho key, false nếu không có.
+func (t *lockTracker) takeAcquisition(key string) (lockAcquisition, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	acquisition, ok := t.acquisitions[key]
+	delete(t.acquisitions, key)
+	return acquisition, ok
+}
+
+// takeLock trả về và xóa tham chiếu tới khóa vừa lấy được cho key, nil nếu không có. Lần chạy
+// theo lịch nhận tham chiếu mà gocron giải phóng sau khi job kết thúc để có thể giải phóng sớm
+// hơn khi hết thời gian chạy.
+func (t *lockTracker) takeLock(key string) gocron.Lock {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lock, ok := t.acquired[key]
+	delete(t.acquired, key)
+	if !ok {
+		return nil
+	}
+	return lock
+}
+
+// takeUnpaused trả về và xóa đánh dấu job có key đã được kiểm tra không bị tạm dừng khi lấy khóa
+// đang được giữ, để lần chạy theo lịch không đọc lại trạng thái tạm dừng.
+func (t *lockTracker) takeUnpaused(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	unpaused := t.unpaused[key]
+	delete(t.unpaused, key)
+	return unpaused
+}
+
+// wrap bọc locker để mọi khóa lấy được đều được ghi nhận cho tới khi Unlock.
+func (t *lockTracker) wrap(locker gocron.Locker) gocron.Locker {
+	return &trackingLocker{Locker: locker, tracker: t}
+}
+
+// releaseAll giải phóng tất cả các khóa đang được giữ, được gọi khi Shutdown hết thời gian chờ.
+// Lần Unlock sau đó của gocron trên các khóa này sẽ thất bại và được bỏ qua.
+func (t *lockTracker) releaseAll(ctx context.Context) {
+	t.mu.Lock()
+	locks := t.locks
+	t.locks = make(map[string]gocron.Lock)
+	t.holders = make(map[string]int)
+	t.acquisitions = make(map[string]lockAcquisition)
+	t.acquired = make(map[string]*trackedLock)
+	t.unpaused = make(map[string]bool)
+	t.mu.Unlock()
+
+	for key, lock := range locks {
+		if err := lock.Unlock(ctx); err != nil {
+			t.telemetry.logger().Warn("scheduler: failed to release lock", slog.String("key", key), slog.Any("error", err))
+		}
+	}
+}
+
+// trackingLocker bọc một gocron.Locker và ghi nhận các khóa vào lockTracker.
+type trackingLocker struct {
+	gocron.Locker
+	tracker *lockTracker
+}
+
+// Lock lấy khóa từ locker gốc và ghi nhận khóa nếu thành công.
+func (l *trackingLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
+	l.tracker.mu.Lock()
+	skip := l.tracker.unlocked[key]
+	l.tracker.mu.Unlock()
+	if skip {
+		return noopLock{}, nil
+	}
+	checked := l.tracker.paused != nil && !isTriggered(ctx)
+	if checked && l.tracker.paused(key) {
+		// Lần chạy theo lịch của job đang bị tạm dừng bị bỏ qua mà không lấy khóa
+		l.tracker.telemetry.logger().Debug("scheduler: job paused, skipping run", slog.String("key", key))
+		return nil, ErrJobPaused
+	}
+
+	acquisition := lockAcquisition{start: time.Now()}
+	lock, err := l.Locker.Lock(ctx, key)
+	acquisition.end = time.Now()
+	acquired := err == nil && lock != nil
+	l.tracker.telemetry.metrics().LockAcquired(key, acquisition.end.Sub(acquisition.start), acquired)
+
+	if !acquired {
+		l.tracker.telemetry.metrics().JobSkipped(key)
+		traceSkippedRun(l.tracker.telemetry.tracer(), key, acquisition, err)
+		logger := l.tracker.telemetry.logger()
+		if err == nil || errors.Is(err, ErrFailedToAcquireLock) {
+			// Khóa đang được giữ bởi instance khác
+			logger.Debug("scheduler: lock not acquired, skipping run", slog.String("key", key))
+		} else {
+			logger.Warn("scheduler: failed to acquire lock, skipping run", slog.String("key", key), slog.Any("error", err))
+		}
+		return lock, err
+	}
+	l.tracker.telemetry.logger().Debug("scheduler: lock acquired", slog.String("key", key))
+
+	tracked := &trackedLock{Lock: lock, key: key, tracker: l.tracker}
+	l.tracker.mu.Lock()
+	l.tracker.locks[key] = lock
+	l.tracker.holders[key] = 1
+	l.tracker.acquisitions[key] = acquisition
+	l.tracker.acquired[key] = tracked
+	if checked {
+		l.tracker.unpaused[key] = true
+	}
+	l.tracker.mu.Unlock()
+
+	return tracked, nil
+}
+
+// trackedLock bọc gocron.Lock để xóa khỏi lockTracker khi được giải phóng.
+type trackedLock struct {
+	gocron.Lock
+	key      string
+	tracker  *lockTracker
+	released atomic.Bool
+}
+
+// Unlock bỏ tham chiếu tới khóa; khi không còn tham chiếu nào, khóa được xóa khỏi lockTracker
+// và khóa gốc được giải phóng. Các lần Unlock sau lần đầu không có tác dụng.
+//
+// gocron truyền context của job, context này bị hủy khi job bị xóa khỏi scheduler trong lúc
+// đang chạy (ví dụ khi SyncJobs lên lịch lại job); khóa vẫn được giải phóng trong trường hợp đó.
+func (l *trackedLock) Unlock(ctx context.Context) error {
+	if l.released.Swap(true) {
+		return nil
+	}
+
+	l.tracker.mu.Lock()
+	if l.tracker.locks[l.key] == l.Lock {
+		l.tracker.holders[l.key]--
+		if l.tracker.holders[l.key] > 0 {
+			// Khóa vẫn được dùng bởi lần chạy khác
+			l.tracker.mu.Unlock()
+			return nil
+		}
+		delete(l.tracker.locks, l.key)
+		delete(l.tracker.holders, l.key)
+		delete(l.tracker.acquisitions, l.key)
+		delete(l.tracker.acquired, l.key)
+		delete(l.tracker.unpaused, l.key)
+	}
+	l.tracker.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	return l.Lock.Unlock(ctx)
+}
+
+// noopLock là gocron.Lock cho các job không lấy khóa phân tán.
+type noopLock struct{}
+
+// Unlock không làm gì.
+func (noopLock) Unlock(ctx context.Context) error { return nil }
+
+// runJob thực thi jobFun với context riêng cho lần chạy này.
+//
+// Context bị hủy khi scheduler dừng, hoặc khi khóa phân tán của job (nếu hỗ trợ
+// LockLostNotifier) bị mất; trong trường hợp sau EventLockLost được phát. Nếu job thất bại,
+// jobFun được thử lại theo RetryPolicy của job trong khi vẫn giữ khóa; EventJobRetrying được
+// phát trước mỗi lần chờ thử lại.
+//
+// Nếu lần chạy (tính cả các lần thử lại) vượt quá timeout của job, context bị hủy,
+// EventJobTimeout được phát, tham chiếu lock của lần chạy tới khóa phân tán (nil nếu không có)
+// được giải phóng và runJob trả về ErrJobTimeout ngay mà không chờ jobFun kết thúc, để job treo
+// không chặn các lần chạy sau. jobFun chưa trả về được theo dõi qua abandon cho tới khi kết thúc.
+//
+// Mỗi lần chạy được ghi nhận trong một span SpanJobRun; context của span được truyền cho jobFun.
+func (m *manager) runJob(def *jobDefinition, jobFun JobFunc, scheduledAt time.Time, lock gocron.Lock) (err error) {
+	startedAt := time.Now()
+	var attempt atomic.Int64
+
+	ctx, span := m.startJobSpan(m.runContext(), def)
+	defer func() {
+		endJobSpan(span, err)
+		m.recordRun(def, scheduledAt, startedAt, int(attempt.Load()), err)
+	}()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if notifier, ok := m.locks.get(def.name).(LockLostNotifier); ok {
+		done := make(chan struct{})
+		defer close(done)
+
+		go func() {
+			select {
+			case <-notifier.Lost():
+				cancel()
+				m.events.emit(Event{
+					Type:    EventLockLost,
+					JobName: def.name,
+					Tags:    def.tags,
+					Err:     notifier.Err(),
+				})
+			case <-done:
+			}
+		}()
+	}
+
+	timeout := def.timeout
+	if timeout == 0 {
+		timeout = m.defaultTimeout
+	}
+	if timeout <= 0 {
+		return m.runAttempts(ctx, def, jobFun, &attempt)
+	}
+
+	ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+	defer cancelTimeout()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- m.runAttempts(ctx, def, jobFun, &attempt)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// Scheduler dừng hoặc khóa bị mất: chờ job kết thúc như bình thường
+			return <-done
+		}
+	}
+
+	m.events.emit(Event{Type: EventJobTimeout, JobName: def.name, Tags: def.tags, Err: ErrJobTimeout, Duration: timeout})
+	m.abandon(def.name, done)
+
+	// Bỏ tham chiếu của lần chạy này tới khóa phân tán; khóa chỉ được giải phóng khi không còn
+	// lần chạy nào dùng chung (ví dụ lần chạy qua RunNow), lần Unlock sau đó không có tác dụng
+	if lock != nil {
+		releaseCtx, cancelRelease := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelRelease()
+		if err := lock.Unlock(releaseCtx); err != nil {
+			m.telemetry.logger().Warn("scheduler: failed to release lock", slog.String("key", def.name), slog.Any("error", err))
+		}
+	}
+
+	return ErrJobTimeout
+}
+
+// abandon theo dõi lần chạy của job name đã hết thời gian nhưng hàm job chưa trả về (done chưa
+// nhận được kết quả): lần chạy được báo là đang chạy qua Jobs và Stop, Shutdown chờ nó kết thúc.
+// Lần chạy này có thể chạy chồng với lần chạy kế tiếp vì khóa của job đã được giải phóng.
+func (m *manager) abandon(name string, done <-chan error) {
+	m.orphansMu.Lock()
+	m.orphans[name]++
+	m.orphansMu.Unlock()
+
+	m.abandoned.Add(1)
+	go func() {
+		defer m.abandoned.Done()
+		<-done
+
+		m.orphansMu.Lock()
+		defer m.orphansMu.Unlock()
+		if m.orphans[name]--; m.orphans[name] == 0 {
+			delete(m.orphans, name)
+		}
+	}()
+}
+
+// orphaned kiểm tra job name có lần chạy đã hết thời gian nhưng chưa trả về hay không.
+func (m *manager) orphaned(name string) bool {
+	m.orphansMu.Lock()
+	defer m.orphansMu.Unlock()
+	return m.orphans[name] > 0
+}
+
+// runAttempts thực thi jobFun và thử lại theo RetryPolicy của job cho tới khi thành công,
+// hết số lần thử, lỗi không được thử lại hoặc ctx bị hủy. Số thứ tự của lần thử hiện tại
+// được ghi vào current.
+func (m *manager) runAttempts(ctx context.Context, def *jobDefinition, jobFun JobFunc, current *atomic.Int64) error {
+	for attempt := 1; ; attempt++ {
+		current.Store(int64(attempt))
+		err := m.runAttempt(ctx, def, jobFun, attempt)
+		if err == nil || ctx.Err() != nil || !def.retry.shouldRetry(attempt, err) {
+			return err
+		}
+
+		m.events.emit(Event{Type: EventJobRetrying, JobName: def.name, Tags: def.tags, Err: err, Attempt: attempt})
+		trace.SpanFromContext(ctx).AddEvent("scheduler.job.retry", trace.WithAttributes(
+			AttrJobAttempt.Int(attempt),
+			attribute.String("error", err.Error()),
+		))
+
+		timer := time.NewTimer(def.retry.delay(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+	}
+}
+
+// runAttempt thực thi một lần chạy của jobFun, được bọc bởi middleware của Manager và của job.
+// Panic trong jobFun hoặc middleware được chuyển thành *PanicError. Kết quả được phát qua
+// EventJobStarted, EventJobSucceeded và EventJobFailed.
+func (m *manager) runAttempt(ctx context.Context, def *jobDefinition, jobFun JobFunc, attempt int) error {
+	ctx = context.WithValue(ctx, jobInfoKey{}, JobInfo{Name: def.name, Tags: def.tags, Attempt: attempt})
+	trace.SpanFromContext(ctx).SetAttributes(AttrJobAttempt.Int(attempt))
+
+	m.events.emit(Event{Type: EventJobStarted, JobName: def.name, Tags: def.tags, Attempt: attempt})
+
+	start := time.Now()
+	err := callJob(ctx, m.chain.wrap(jobFun, def.middleware))
+
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		m.events.emit(Event{Type: EventJobPanicked, JobName: def.name, Tags: def.tags, Err: err, Attempt: attempt})
+	}
+
+	event := Event{
+		Type:     EventJobSucceeded,
+		JobName:  def.name,
+		Tags:     def.tags,
+		Err:      err,
+		Duration: time.Since(start),
+		Attempt:  attempt,
+	}
+	if err != nil {
+		event.Type = EventJobFailed
+	}
+	m.events.emit(event)
+
+	return err
+}
+
+// PanicError là lỗi của lần chạy job bị panic.
+type PanicError struct {
+	// Value là giá trị được truyền cho panic
+	Value interface{}
+
+	// Stack là stack trace của goroutine tại thời điểm panic
+	Stack []byte
+}
+
+// Error triển khai error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("scheduler: job panicked: %v", e.Value)
+}
+
+// Unwrap trả về giá trị panic nếu đó là error, để hỗ trợ errors.Is và errors.As.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// callJob gọi jobFun và chuyển panic thành *PanicError.
+func callJob(ctx context.Context, jobFun JobFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return jobFun(ctx)
+}
+
+// funcArgs chuyển params thành các tham số cho hàm có kiểu fnType. sliced là true nếu params
+// cuối cùng là slice của tham số variadic và được truyền nguyên qua CallSlice. Trả về
+// gocron.ErrWrongParams nếu số lượng hoặc kiểu của params không khớp.
+func funcArgs(fnType reflect.Type, params []interface{}) (in []reflect.Value, sliced bool, err error) {
+	n := fnType.NumIn()
+	variadic := fnType.IsVariadic()
+	if len(params) != n && (!variadic || len(params) < n-1) {
+		return nil, false, gocron.ErrWrongParams
+	}
+	sliced = variadic && len(params) == n && assignableParam(params[n-1], fnType.In(n-1))
+
+	in = make([]reflect.Value, len(params))
+	for i, param := range params {
+		want := fnType.In(min(i, n-1))
+		if variadic && i >= n-1 && !sliced {
+			want = want.Elem()
+		}
+		if !assignableParam(param, want) {
+			return nil, false, gocron.ErrWrongParams
+		}
+		if param == nil {
+			in[i] = reflect.Zero(want)
+		} else {
+			in[i] = reflect.ValueOf(param)
+		}
+	}
+	return in, sliced, nil
+}
+
+// assignableParam kiểm tra param có thể được truyền cho tham số kiểu want hay không.
+func assignableParam(param interface{}, want reflect.Type) bool {
+	if param == nil {
+		switch want.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return true
+		}
+		return false
+	}
+	return reflect.TypeOf(param).AssignableTo(want)
+}
+
+// callFunc gọi hàm fn với các tham số in (qua CallSlice nếu sliced), trả về giá trị error đầu
+// tiên fn trả về (nếu có).
+func callFunc(fn reflect.Value, in []reflect.Value, sliced bool) error {
+	var out []reflect.Value
+	if sliced {
+		out = fn.CallSlice(in)
+	} else {
+		out = fn.Call(in)
+	}
+	for _, value := range out {
+		if err, ok := value.Interface().(error); ok && err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scheduledTime trả về thời điểm lần chạy hiện tại của job được lên lịch, hoặc thời điểm
+// hiện tại nếu không xác định được.
+func scheduledTime(job *gocron.Job) time.Time {
+	if job != nil {
+		if lastRun := job.LastRun(); !lastRun.IsZero() {
+			return lastRun
+		}
+	}
+	return time.Now()
+}
+
+// runContext trả về context gốc cho các lần chạy job, bị hủy khi scheduler dừng.
+func (m *manager) runContext() context.Context {
+	m.runMu.Lock()
+	defer m.runMu.Unlock()
+
+	if m.runCtx == nil || m.runCtx.Err() != nil {
+		m.runCtx, m.cancelRuns = context.WithCancel(context.Background())
+	}
+	return m.runCtx
+}
+
+// cancelRunning hủy context của tất cả các job đang chạy.
+func (m *manager) cancelRunning() {
+	m.runMu.Lock()
+	defer m.runMu.Unlock()
+
+	if m.cancelRuns != nil {
+		m.cancelRuns()
+	}
+}
+
+// functionName trả về tên đầy đủ của hàm, giống cách gocron đặt tên job mặc định.
+func functionName(fn interface{}) string {
+	return runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+}
+
+// Error constants cho việc thực thi job
+var (
+	// ErrJobTimeout được trả về khi lần chạy của job vượt quá thời gian chạy tối đa.
+	ErrJobTimeout = errors.New("scheduler: job timed out")
+)
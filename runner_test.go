@@ -0,0 +1,757 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-co-op/gocron"
+	"github.com/stretchr/testify/assert"
+)
+
+// notifyingLock là gocron.Lock giả lập có hỗ trợ LockLostNotifier.
+type notifyingLock struct {
+	lost chan struct{}
+	err  error
+}
+
+func (l *notifyingLock) Unlock(ctx context.Context) error { return nil }
+func (l *notifyingLock) Lost() <-chan struct{}            { return l.lost }
+func (l *notifyingLock) Err() error                       { return l.err }
+
+// recordingLocker ghi lại các lock key được yêu cầu.
+type recordingLocker struct {
+	mu   sync.Mutex
+	keys []string
+	lock gocron.Lock
+}
+
+func (l *recordingLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.keys = append(l.keys, key)
+	return l.lock, nil
+}
+
+func namedTestJob(ctx context.Context) error { return nil }
+
+func TestDoContextUsesFunctionNameAsJobName(t *testing.T) {
+	scheduler := NewScheduler()
+
+	job, err := scheduler.Every(1).Hours().DoContext(namedTestJob)
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if got := job.GetName(); got != "go.fork.vn/scheduler.namedTestJob" {
+		t.Errorf("Expected job name of the original function, got %q", got)
+	}
+
+	job, err = scheduler.Every(1).Hours().Name("custom").DoContext(namedTestJob)
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if got := job.GetName(); got != "custom" {
+		t.Errorf("Expected job name custom, got %q", got)
+	}
+}
+
+func TestDoContextCancelsJobWhenLockIsLost(t *testing.T) {
+	scheduler := NewScheduler()
+
+	lock := &notifyingLock{lost: make(chan struct{}), err: ErrLockOwnershipLost}
+	locker := &recordingLocker{lock: lock}
+	scheduler.WithDistributedLocker(locker)
+
+	events := make(chan Event, 1)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventLockLost {
+			events <- event
+		}
+	})
+
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	_, err := scheduler.Every(1).Hours().Name("exactly-once").Tag("billing").DoContext(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		result <- ctx.Err()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Job did not start")
+	}
+
+	close(lock.lost)
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Job context was not cancelled after the lock was lost")
+	}
+
+	select {
+	case event := <-events:
+		if event.Type != EventLockLost {
+			t.Errorf("Expected EventLockLost, got %s", event.Type)
+		}
+		if event.JobName != "exactly-once" {
+			t.Errorf("Expected job name exactly-once, got %q", event.JobName)
+		}
+		if len(event.Tags) != 1 || event.Tags[0] != "billing" {
+			t.Errorf("Expected tags [billing], got %v", event.Tags)
+		}
+		if !errors.Is(event.Err, ErrLockOwnershipLost) {
+			t.Errorf("Expected ErrLockOwnershipLost, got %v", event.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("EventLockLost was not emitted")
+	}
+
+	locker.mu.Lock()
+	defer locker.mu.Unlock()
+	if len(locker.keys) == 0 || locker.keys[0] != "exactly-once" {
+		t.Errorf("Expected lock key exactly-once, got %v", locker.keys)
+	}
+}
+
+func TestLockTrackerForgetsUnlockedLocks(t *testing.T) {
+	tracker := newLockTracker()
+	locker := tracker.wrap(&recordingLocker{lock: &mockLock{}})
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	if tracker.get("job") == nil {
+		t.Fatal("Expected lock to be tracked after Lock")
+	}
+
+	if err := lock.Unlock(context.Background()); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+	if tracker.get("job") != nil {
+		t.Fatal("Expected lock to be forgotten after Unlock")
+	}
+}
+
+func TestDoContextCancelledOnStop(t *testing.T) {
+	scheduler := NewScheduler()
+
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	_, err := scheduler.Every(1).Hours().DoContext(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		result <- ctx.Err()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+
+	select {
+	case <-started:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Job did not start")
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		scheduler.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop did not return, job context was not cancelled")
+	}
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	// Sau khi start lại, job mới nhận context chưa bị hủy
+	scheduler.Clear()
+	fresh := make(chan error, 1)
+	if _, err := scheduler.Every(1).Hours().DoContext(func(ctx context.Context) error {
+		fresh <- ctx.Err()
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case err := <-fresh:
+		if err != nil {
+			t.Errorf("Expected fresh context after restart, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Job did not run after restart")
+	}
+}
+
+func TestDoContextEmitsJobEvents(t *testing.T) {
+	scheduler := NewScheduler()
+
+	jobErr := errors.New("boom")
+	events := make(chan Event, 10)
+	scheduler.OnEvent(func(event Event) { events <- event })
+
+	infos := make(chan JobInfo, 1)
+	_, err := scheduler.Every(1).Hours().Name("failing").Tag("reports").DoContext(func(ctx context.Context) error {
+		info, _ := JobInfoFromContext(ctx)
+		infos <- info
+		time.Sleep(10 * time.Millisecond)
+		return jobErr
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	var received []Event
+	for len(received) < 2 {
+		select {
+		case event := <-events:
+			received = append(received, event)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected 2 events, got %v", received)
+		}
+	}
+
+	if received[0].Type != EventJobStarted || received[0].JobName != "failing" {
+		t.Errorf("Expected EventJobStarted for failing, got %+v", received[0])
+	}
+	if received[1].Type != EventJobFailed || !errors.Is(received[1].Err, jobErr) {
+		t.Errorf("Expected EventJobFailed with job error, got %+v", received[1])
+	}
+	if received[1].Duration < 10*time.Millisecond {
+		t.Errorf("Expected duration of at least 10ms, got %v", received[1].Duration)
+	}
+
+	info := <-infos
+	if info.Name != "failing" || len(info.Tags) != 1 || info.Tags[0] != "reports" {
+		t.Errorf("Unexpected job info %+v", info)
+	}
+}
+
+func TestJobInfoFromContextWithoutJob(t *testing.T) {
+	if _, ok := JobInfoFromContext(context.Background()); ok {
+		t.Error("Expected no job info in background context")
+	}
+}
+
+// unlockCountingLock là gocron.Lock giả lập đếm số lần Unlock.
+type unlockCountingLock struct {
+	mu       sync.Mutex
+	unlocked int
+}
+
+func (l *unlockCountingLock) Unlock(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.unlocked++
+	return nil
+}
+
+func (l *unlockCountingLock) count() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.unlocked
+}
+
+func TestDoContextTimeout(t *testing.T) {
+	lock := &unlockCountingLock{}
+	scheduler := NewScheduler().WithDistributedLocker(&recordingLocker{lock: lock})
+
+	timedOut := make(chan Event, 1)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobTimeout {
+			timedOut <- event
+		}
+	})
+
+	release := make(chan struct{})
+	defer close(release)
+	jobErr := make(chan error, 1)
+	_, err := scheduler.Every(1).Hours().Name("slow").Tag("reports").
+		Timeout(50 * time.Millisecond).
+		DoContext(func(ctx context.Context) error {
+			<-ctx.Done()
+			jobErr <- ctx.Err()
+			<-release // Job không trả về ngay sau khi bị hủy
+			return ctx.Err()
+		})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+
+	select {
+	case event := <-timedOut:
+		if event.JobName != "slow" || !errors.Is(event.Err, ErrJobTimeout) || event.Duration != 50*time.Millisecond {
+			t.Errorf("Unexpected timeout event: %+v", event)
+		}
+		if len(event.Tags) != 1 || event.Tags[0] != "reports" {
+			t.Errorf("Expected tags of the job, got %v", event.Tags)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected EventJobTimeout")
+	}
+
+	if err := <-jobErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected job context to exceed its deadline, got %v", err)
+	}
+
+	// Khóa được giải phóng ngay, không chờ job trả về
+	deadline := time.Now().Add(time.Second)
+	for lock.count() == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if lock.count() == 0 {
+		t.Error("Expected lock to be released on timeout")
+	}
+}
+
+func TestDoContextTimeoutUnblocksSingletonMode(t *testing.T) {
+	scheduler := NewScheduler()
+
+	var mu sync.Mutex
+	started := 0
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobStarted {
+			mu.Lock()
+			started++
+			mu.Unlock()
+		}
+	})
+
+	release := make(chan struct{})
+	_, err := scheduler.Every(100 * time.Millisecond).SingletonMode().
+		Timeout(30 * time.Millisecond).
+		DoContext(func(ctx context.Context) error {
+			<-release // Job treo và bỏ qua ctx
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	time.Sleep(350 * time.Millisecond)
+	close(release) // Stop chờ các lần chạy đã hết thời gian trả về
+	scheduler.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if started < 2 {
+		t.Errorf("Expected hung job not to block later runs, got %d runs", started)
+	}
+}
+
+func TestDoContextTimeoutTracksAbandonedRun(t *testing.T) {
+	scheduler := NewScheduler()
+
+	timedOut := make(chan struct{}, 1)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobTimeout {
+			timedOut <- struct{}{}
+		}
+	})
+
+	release := make(chan struct{})
+	returned := make(chan struct{})
+	_, err := scheduler.Every(1).Hours().Name("hung").Timeout(20 * time.Millisecond).
+		DoContext(func(ctx context.Context) error {
+			<-release // Job bỏ qua ctx
+			close(returned)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	select {
+	case <-timedOut:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected EventJobTimeout")
+	}
+
+	// Lần chạy đã hết thời gian vẫn được báo là đang chạy cho tới khi hàm job trả về
+	jobs := scheduler.Jobs()
+	assert.Len(t, jobs, 1)
+	assert.True(t, jobs[0].Running)
+
+	stopped := make(chan struct{})
+	go func() {
+		scheduler.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		t.Fatal("Expected Stop to wait for the abandoned run")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Stop to return once the abandoned run returns")
+	}
+	<-returned
+	assert.False(t, scheduler.Jobs()[0].Running)
+}
+
+func TestDoContextTimeoutKeepsLockSharedWithRunNow(t *testing.T) {
+	store := NewMemoryLockStore()
+	locker, err := NewMemoryLocker(store, testMemoryLockerOptions("node-a"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	other, err := NewMemoryLocker(store, testMemoryLockerOptions("node-b"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	scheduler := NewScheduler().WithDistributedLocker(locker)
+	timedOut := make(chan struct{}, 2)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobTimeout {
+			timedOut <- struct{}{}
+		}
+	})
+
+	var calls atomic.Int32
+	started := make(chan struct{}, 2)
+	hung := make(chan struct{})
+	finish := make(chan struct{})
+	_, err = scheduler.Every(1).Hours().Name("report").Timeout(200 * time.Millisecond).
+		DoContext(func(ctx context.Context) error {
+			started <- struct{}{}
+			if calls.Add(1) == 1 {
+				<-hung // Lần chạy theo lịch bỏ qua ctx
+				return nil
+			}
+			<-finish
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	<-started
+	time.Sleep(100 * time.Millisecond)
+
+	// Lần chạy qua RunNow dùng chung khóa của lần chạy theo lịch
+	handle, err := scheduler.RunNow("report")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	<-started
+
+	select {
+	case <-timedOut:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected EventJobTimeout")
+	}
+	_, err = other.Lock(context.Background(), "report")
+	assert.Error(t, err, "lock must stay held while the triggered run is in progress")
+
+	close(finish)
+	assert.NoError(t, handle.Wait(context.Background()))
+	lock, err := other.Lock(context.Background(), "report")
+	if err != nil {
+		t.Fatalf("Expected lock to be released after the triggered run: %v", err)
+	}
+	_ = lock.Unlock(context.Background())
+
+	close(hung)
+	scheduler.Stop()
+}
+
+func TestDoContextDefaultTimeout(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DefaultTimeout = 1
+	scheduler := NewSchedulerWithConfig(cfg)
+
+	timedOut := make(chan Event, 2)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobTimeout {
+			timedOut <- event
+		}
+	})
+
+	_, err := scheduler.Every(1).Hours().Name("default").DoContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	// Timeout riêng của job được ưu tiên
+	_, err = scheduler.Every(1).Hours().Name("custom").Timeout(20 * time.Millisecond).DoContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	var names []string
+	for len(names) < 2 {
+		select {
+		case event := <-timedOut:
+			names = append(names, event.JobName)
+		case <-time.After(3 * time.Second):
+			t.Fatalf("Expected both jobs to time out, got %v", names)
+		}
+	}
+	if names[0] != "custom" || names[1] != "default" {
+		t.Errorf("Expected custom timeout before default timeout, got %v", names)
+	}
+}
+
+func TestDoContextInvalidTimeout(t *testing.T) {
+	scheduler := NewScheduler()
+
+	_, err := scheduler.Every(1).Hours().Timeout(-time.Second).DoContext(namedTestJob)
+	if !errors.Is(err, ErrInvalidJobTimeout) {
+		t.Fatalf("Expected ErrInvalidJobTimeout, got %v", err)
+	}
+	if jobs := scheduler.(*manager).Jobs(); len(jobs) != 0 {
+		t.Errorf("Expected invalid job not to be scheduled, got %d jobs", len(jobs))
+	}
+}
+
+func TestDoContextRecoversPanics(t *testing.T) {
+	lock := &unlockCountingLock{}
+	scheduler := NewScheduler().WithDistributedLocker(&recordingLocker{lock: lock})
+
+	events := make(chan Event, 10)
+	scheduler.OnEvent(func(event Event) {
+		events <- event
+	})
+
+	_, err := scheduler.Every(1).Hours().Name("panicky").DoContext(func(ctx context.Context) error {
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+
+	var got []Event
+	for len(got) < 3 {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected job events, got %+v", got)
+		}
+	}
+	scheduler.Stop()
+
+	if got[0].Type != EventJobStarted || got[1].Type != EventJobPanicked || got[2].Type != EventJobFailed {
+		t.Fatalf("Expected started, panicked and failed events, got %+v", got)
+	}
+
+	var panicErr *PanicError
+	if !errors.As(got[2].Err, &panicErr) {
+		t.Fatalf("Expected *PanicError, got %v", got[2].Err)
+	}
+	if panicErr.Value != "boom" || panicErr.Error() != "scheduler: job panicked: boom" {
+		t.Errorf("Unexpected panic error: %v", panicErr)
+	}
+	if !strings.Contains(string(panicErr.Stack), "TestDoContextRecoversPanics") {
+		t.Errorf("Expected stack trace of the panicking job, got %s", panicErr.Stack)
+	}
+
+	if lock.count() != 1 {
+		t.Errorf("Expected lock to be released after panic, got %d unlocks", lock.count())
+	}
+}
+
+func TestPanicErrorUnwrap(t *testing.T) {
+	err := &PanicError{Value: assert.AnError}
+	if !errors.Is(err, assert.AnError) {
+		t.Error("Expected PanicError to unwrap error values")
+	}
+	if (&PanicError{Value: "boom"}).Unwrap() != nil {
+		t.Error("Expected nil for non-error values")
+	}
+}
+
+func TestDoRunsThroughJobRunner(t *testing.T) {
+	scheduler := NewScheduler()
+
+	events := make(chan Event, 10)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type != EventJobStarted {
+			events <- event
+		}
+	})
+
+	_, err := scheduler.Every(1).Hours().Name("plain-panic").Do(func() {
+		panic("boom")
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	_, err = scheduler.Every(1).Hours().Name("plain-error").Do(func(msg string) error {
+		return errors.New(msg)
+	}, "failed")
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	failed := make(map[string]error)
+	panicked := false
+	for len(failed) < 2 {
+		select {
+		case event := <-events:
+			switch event.Type {
+			case EventJobPanicked:
+				panicked = event.JobName == "plain-panic"
+			case EventJobFailed:
+				failed[event.JobName] = event.Err
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected both jobs to fail, got %v", failed)
+		}
+	}
+
+	if !panicked {
+		t.Error("Expected EventJobPanicked for plain-panic")
+	}
+	var panicErr *PanicError
+	if !errors.As(failed["plain-panic"], &panicErr) {
+		t.Errorf("Expected *PanicError, got %v", failed["plain-panic"])
+	}
+	if err := failed["plain-error"]; err == nil || err.Error() != "failed" {
+		t.Errorf("Expected error returned by the job, got %v", err)
+	}
+}
+
+func TestDoKeepsGocronValidation(t *testing.T) {
+	scheduler := NewScheduler()
+
+	if _, err := scheduler.Every(1).Hours().Do("not a function"); !errors.Is(err, gocron.ErrNotAFunction) {
+		t.Errorf("Expected ErrNotAFunction, got %v", err)
+	}
+	if _, err := scheduler.Every(1).Hours().Do(func(string) {}); !errors.Is(err, gocron.ErrWrongParams) {
+		t.Errorf("Expected ErrWrongParams, got %v", err)
+	}
+
+	job, err := scheduler.Every(1).Hours().Do(namedTestJob, context.Background())
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	if got := job.GetName(); got != "go.fork.vn/scheduler.namedTestJob" {
+		t.Errorf("Expected job name of the original function, got %q", got)
+	}
+	if jobs := scheduler.(*manager).Jobs(); len(jobs) != 1 {
+		t.Errorf("Expected only the valid job to be scheduled, got %d jobs", len(jobs))
+	}
+}
+
+func TestDoSupportsVariadicFunctions(t *testing.T) {
+	scheduler := NewScheduler()
+
+	failed := make(chan Event, 10)
+	scheduler.OnEvent(func(event Event) {
+		if event.Type == EventJobFailed {
+			failed <- event
+		}
+	})
+
+	join := func(prefix string, parts ...string) error {
+		return errors.New(prefix + strings.Join(parts, ","))
+	}
+	jobs := map[string][]interface{}{
+		"none":   {"none:"},
+		"spread": {"spread:", "a", "b"},
+		"sliced": {"sliced:", []string{"a", "b"}},
+	}
+	for name, params := range jobs {
+		if _, err := scheduler.Every(1).Hours().Name(name).Do(join, params...); err != nil {
+			t.Fatalf("Failed to schedule job %s: %v", name, err)
+		}
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	got := make(map[string]string)
+	for len(got) < len(jobs) {
+		select {
+		case event := <-failed:
+			got[event.JobName] = event.Err.Error()
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected all jobs to run, got %v", got)
+		}
+	}
+	assert.Equal(t, map[string]string{"none": "none:", "spread": "spread:a,b", "sliced": "sliced:a,b"}, got)
+}
+
+func TestDoRejectsMismatchedParams(t *testing.T) {
+	scheduler := NewScheduler()
+
+	tests := []struct {
+		name   string
+		jobFun interface{}
+		params []interface{}
+	}{
+		{"too many", func(string) {}, []interface{}{"a", "b"}},
+		{"wrong type", func(string) {}, []interface{}{1}},
+		{"nil value", func(int) {}, []interface{}{nil}},
+		{"variadic too few", func(string, ...int) {}, nil},
+		{"variadic wrong element", func(string, ...int) {}, []interface{}{"a", "b"}},
+	}
+	for _, tt := range tests {
+		if _, err := scheduler.Every(1).Hours().Name(tt.name).Do(tt.jobFun, tt.params...); !errors.Is(err, gocron.ErrWrongParams) {
+			t.Errorf("%s: expected ErrWrongParams, got %v", tt.name, err)
+		}
+	}
+	assert.Empty(t, scheduler.GetScheduler().Jobs(), "no job should be scheduled with mismatched params")
+
+	// Fluent chain được hủy, job kế tiếp không bị ảnh hưởng
+	job, err := scheduler.Every(1).Hours().Do(func(ctx context.Context, names ...string) {}, context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	assert.NotEqual(t, "variadic wrong element", job.GetName())
+	assert.Len(t, scheduler.GetScheduler().Jobs(), 1)
+}
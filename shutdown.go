@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// ShutdownError được trả về bởi Shutdown khi ctx hết hạn trước khi các job đang chạy kết thúc.
+type ShutdownError struct {
+	// RunningJobs là tên các job vẫn đang chạy khi hết thời gian chờ
+	RunningJobs []string
+
+	// Err là lỗi của context (context.DeadlineExceeded hoặc context.Canceled)
+	Err error
+}
+
+// Error triển khai error interface.
+func (e *ShutdownError) Error() string {
+	return "scheduler: shutdown did not complete, jobs still running: " + strings.Join(e.RunningJobs, ", ")
+}
+
+// Unwrap trả về lỗi của context để hỗ trợ errors.Is(err, context.DeadlineExceeded).
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
+}
+
+// Shutdown dừng scheduler một cách an toàn.
+//
+// Luồng thực thi:
+//  1. Ngừng lên lịch các lần chạy mới
+//  2. Chờ các job đang chạy (kể cả các lần chạy qua RunNow và các lần chạy đã hết thời gian
+//     nhưng chưa trả về) kết thúc cho tới khi ctx hết hạn
+//  3. Nếu ctx hết hạn: hủy context của các job đăng ký qua DoContext và giải phóng
+//     các khóa phân tán đang được giữ
+//  4. Dừng leader elector (nếu có) và từ bỏ leadership
+//
+// Shutdown trả về *ShutdownError chứa tên các job vẫn đang chạy nếu ctx hết hạn trước
+// khi tất cả các job kết thúc, ngược lại trả về nil.
+func (m *manager) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		m.Scheduler.Stop()
+		m.triggered.Wait()
+		m.abandoned.Wait()
+	}()
+
+	select {
+	case <-stopped:
+		m.cancelRunning()
+		m.stopElector()
+		m.telemetry.logger().Info("scheduler: shut down gracefully")
+		return nil
+	case <-ctx.Done():
+	}
+
+	running := m.runningJobs()
+	m.telemetry.logger().Warn("scheduler: shutdown timed out, cancelling running jobs", slog.Any("jobs", running))
+
+	m.cancelRunning()
+
+	releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	m.locks.releaseAll(releaseCtx)
+
+	m.stopElector()
+
+	if len(running) == 0 {
+		// Các job đã kết thúc ngay khi ctx hết hạn
+		return nil
+	}
+	return &ShutdownError{RunningJobs: running, Err: ctx.Err()}
+}
+
+// runningJobs trả về tên các job đang chạy, kể cả các lần chạy được kích hoạt qua RunNow.
+func (m *manager) runningJobs() []string {
+	var names []string
+	for _, status := range m.Jobs() {
+		if status.Running {
+			names = append(names, status.Name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,133 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestShutdownWaitsForRunningJobs(t *testing.T) {
+	scheduler := NewScheduler()
+
+	started := make(chan struct{})
+	finished := make(chan struct{})
+	_, err := scheduler.Every(1).Hours().Name("drain").DoContext(func(ctx context.Context) error {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		close(finished)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := scheduler.Shutdown(ctx); err != nil {
+		t.Fatalf("Expected shutdown to complete, got %v", err)
+	}
+
+	select {
+	case <-finished:
+	default:
+		t.Error("Expected running job to finish before Shutdown returned")
+	}
+	if scheduler.IsRunning() {
+		t.Error("Scheduler should not be running after Shutdown")
+	}
+}
+
+func TestShutdownReportsRunningJobsAfterDeadline(t *testing.T) {
+	scheduler := NewScheduler()
+
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	_, err := scheduler.Every(1).Hours().Name("stuck").DoContext(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		close(cancelled)
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err = scheduler.Shutdown(ctx)
+
+	var shutdownErr *ShutdownError
+	if !errors.As(err, &shutdownErr) {
+		t.Fatalf("Expected ShutdownError, got %v", err)
+	}
+	if len(shutdownErr.RunningJobs) != 1 || shutdownErr.RunningJobs[0] != "stuck" {
+		t.Errorf("Expected running job stuck, got %v", shutdownErr.RunningJobs)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected error to wrap context.DeadlineExceeded, got %v", err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Error("Expected job context to be cancelled after shutdown deadline")
+	}
+}
+
+func TestShutdownReleasesHeldLocks(t *testing.T) {
+	store := NewMemoryLockStore()
+	locker, err := NewMemoryLocker(store)
+	if err != nil {
+		t.Fatalf("Failed to create memory locker: %v", err)
+	}
+
+	scheduler := NewScheduler().WithDistributedLocker(locker)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	_, err = scheduler.Every(1).Hours().Name("locked").Do(func() {
+		close(started)
+		<-release
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := scheduler.Shutdown(ctx); err == nil {
+		t.Fatal("Expected ShutdownError for job ignoring the deadline")
+	}
+
+	other, err := NewMemoryLocker(store)
+	if err != nil {
+		t.Fatalf("Failed to create memory locker: %v", err)
+	}
+	lock, err := other.Lock(context.Background(), "locked")
+	if err != nil {
+		t.Fatalf("Expected lock to be released by Shutdown, got %v", err)
+	}
+	_ = lock.Unlock(context.Background())
+}
+
+func TestShutdownWhenNotRunning(t *testing.T) {
+	scheduler := NewScheduler()
+
+	if err := scheduler.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected nil error for scheduler that was not started, got %v", err)
+	}
+}
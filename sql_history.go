@@ -0,0 +1,205 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLHistoryTable là tên bảng lịch sử chạy job được SQL HistoryStore sử dụng.
+const SQLHistoryTable = "scheduler_job_runs"
+
+// sqlHistoryStore triển khai HistoryStore sử dụng database/sql làm backend.
+type sqlHistoryStore struct {
+	db     *sql.DB
+	driver string
+}
+
+// NewSQLHistoryStore tạo HistoryStore lưu lịch sử trong bảng SQLHistoryTable.
+//
+// driver là một trong "postgres", "mysql" hoặc "sqlite". Bảng SQLHistoryTable và index theo
+// job được tự động tạo nếu chưa tồn tại. Lịch sử không bị giới hạn; ứng dụng tự xóa các lần
+// chạy cũ nếu cần.
+//
+// Example:
+//
+//	store, err := scheduler.NewSQLHistoryStore(db, scheduler.HistoryDriverPostgres)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithHistory(store)
+func NewSQLHistoryStore(db *sql.DB, driver string) (HistoryStore, error) {
+	if db == nil {
+		return nil, ErrSQLDBNil
+	}
+
+	var schema []string
+	switch driver {
+	case HistoryDriverPostgres:
+		schema = []string{createHistoryTableQueryPostgres, createHistoryIndexQuery}
+	case HistoryDriverMySQL:
+		schema = []string{createHistoryTableQueryMySQL}
+	case HistoryDriverSQLite:
+		schema = []string{createHistoryTableQuerySQLite, createHistoryIndexQuery}
+	default:
+		return nil, ErrUnsupportedHistoryDriver
+	}
+
+	// Kiểm tra kết nối đến database
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, ErrFailedToConnectToSQL
+	}
+
+	for _, query := range schema {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return nil, err
+		}
+	}
+
+	return &sqlHistoryStore{db: db, driver: driver}, nil
+}
+
+// Record triển khai HistoryStore.
+func (s *sqlHistoryStore) Record(ctx context.Context, run JobRun) error {
+	tags, err := encodeHistoryTags(run.Tags)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, s.rebind(insertHistoryQuery),
+		run.JobName,
+		tags,
+		run.ScheduledAt.UnixMicro(),
+		run.StartedAt.UnixMicro(),
+		run.FinishedAt.UnixMicro(),
+		run.InstanceID,
+		run.Outcome,
+		run.Error,
+		run.Attempt,
+	)
+	return err
+}
+
+// List triển khai HistoryStore.
+func (s *sqlHistoryStore) List(ctx context.Context, job string, limit, offset int) ([]JobRun, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit <= 0 {
+		return []JobRun{}, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.rebind(listHistoryQuery), job, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := []JobRun{}
+	for rows.Next() {
+		var (
+			run                                JobRun
+			tags                               string
+			scheduledAt, startedAt, finishedAt int64
+		)
+		if err := rows.Scan(&run.JobName, &tags, &scheduledAt, &startedAt, &finishedAt,
+			&run.InstanceID, &run.Outcome, &run.Error, &run.Attempt); err != nil {
+			return nil, err
+		}
+		if run.Tags, err = decodeHistoryTags(tags); err != nil {
+			return nil, err
+		}
+		run.ScheduledAt = time.UnixMicro(scheduledAt)
+		run.StartedAt = time.UnixMicro(startedAt)
+		run.FinishedAt = time.UnixMicro(finishedAt)
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// encodeHistoryTags mã hóa tags thành mảng JSON để tag chứa dấu phẩy được giữ nguyên, chuỗi
+// rỗng nếu job không có tag.
+func encodeHistoryTags(tags []string) (string, error) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(tags)
+	return string(data), err
+}
+
+// decodeHistoryTags giải mã tags được lưu bởi encodeHistoryTags, nil nếu job không có tag.
+func decodeHistoryTags(tags string) ([]string, error) {
+	if tags == "" {
+		return nil, nil
+	}
+	var decoded []string
+	if err := json.Unmarshal([]byte(tags), &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// rebind chuyển placeholder "?" trong query sang "$n" cho PostgreSQL.
+func (s *sqlHistoryStore) rebind(query string) string {
+	return rebindQuery(s.driver, query)
+}
+
+// rebindQuery chuyển placeholder "?" trong query sang "$n" cho PostgreSQL.
+func rebindQuery(driver, query string) string {
+	if driver != HistoryDriverPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Các câu lệnh SQL cho bảng lịch sử. Các thời điểm được lưu dưới dạng Unix microseconds,
+// tags được lưu dạng mảng JSON (chuỗi rỗng nếu job không có tag).
+const (
+	historyColumns = `job_name VARCHAR(255) NOT NULL,
+	tags TEXT NOT NULL,
+	scheduled_at BIGINT NOT NULL,
+	started_at BIGINT NOT NULL,
+	finished_at BIGINT NOT NULL,
+	instance_id VARCHAR(255) NOT NULL,
+	outcome VARCHAR(32) NOT NULL,
+	error_message TEXT NOT NULL,
+	attempt INT NOT NULL`
+
+	createHistoryTableQueryPostgres = `CREATE TABLE IF NOT EXISTS ` + SQLHistoryTable + ` (
+	id BIGSERIAL PRIMARY KEY,
+	` + historyColumns + `
+)`
+
+	createHistoryTableQueryMySQL = `CREATE TABLE IF NOT EXISTS ` + SQLHistoryTable + ` (
+	id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+	` + historyColumns + `,
+	INDEX ` + SQLHistoryTable + `_job_started (job_name, started_at)
+)`
+
+	createHistoryTableQuerySQLite = `CREATE TABLE IF NOT EXISTS ` + SQLHistoryTable + ` (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	` + historyColumns + `
+)`
+
+	createHistoryIndexQuery = `CREATE INDEX IF NOT EXISTS ` + SQLHistoryTable + `_job_started ON ` + SQLHistoryTable + ` (job_name, started_at)`
+
+	insertHistoryQuery = `INSERT INTO ` + SQLHistoryTable + ` (job_name, tags, scheduled_at, started_at, finished_at, instance_id, outcome, error_message, attempt) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	listHistoryQuery = `SELECT job_name, tags, scheduled_at, started_at, finished_at, instance_id, outcome, error_message, attempt FROM ` + SQLHistoryTable + ` WHERE job_name = ? ORDER BY started_at DESC, id DESC LIMIT ? OFFSET ?`
+)
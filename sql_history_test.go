@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewSQLHistoryStoreValidation(t *testing.T) {
+	if _, err := NewSQLHistoryStore(nil, HistoryDriverSQLite); err != ErrSQLDBNil {
+		t.Errorf("Expected ErrSQLDBNil, got %v", err)
+	}
+
+	db := newTestSQLiteDB(t)
+	if _, err := NewSQLHistoryStore(db, HistoryDriverRedis); err != ErrUnsupportedHistoryDriver {
+		t.Errorf("Expected ErrUnsupportedHistoryDriver, got %v", err)
+	}
+
+	// Bảng đã tồn tại không gây lỗi
+	for i := 0; i < 2; i++ {
+		if _, err := NewSQLHistoryStore(db, HistoryDriverSQLite); err != nil {
+			t.Fatalf("Failed to create history store: %v", err)
+		}
+	}
+}
+
+func TestSQLHistoryStoreRecordAndList(t *testing.T) {
+	store, err := NewSQLHistoryStore(newTestSQLiteDB(t), HistoryDriverSQLite)
+	if err != nil {
+		t.Fatalf("Failed to create history store: %v", err)
+	}
+
+	recordTestRuns(t, store, "report", 5)
+	recordTestRuns(t, store, "other", 2)
+
+	runs, err := store.List(context.Background(), "report", 2, 1)
+	if err != nil {
+		t.Fatalf("Failed to list runs: %v", err)
+	}
+	if got := attempts(runs); got != "[4 3]" {
+		t.Errorf("Expected paginated runs newest first, got %s", got)
+	}
+
+	run := runs[0]
+	if run.JobName != "report" || run.InstanceID != "node-1" || run.Outcome != OutcomeSucceeded {
+		t.Errorf("Unexpected run: %+v", run)
+	}
+	if !reflect.DeepEqual(run.Tags, []string{"reports", "daily"}) {
+		t.Errorf("Expected tags to round trip, got %v", run.Tags)
+	}
+	if run.Duration() != 250*time.Millisecond {
+		t.Errorf("Expected duration 250ms, got %v", run.Duration())
+	}
+
+	failed := JobRun{
+		JobName:    "failing",
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+		Outcome:    OutcomeFailed,
+		Error:      "boom",
+		Attempt:    3,
+	}
+	if err := store.Record(context.Background(), failed); err != nil {
+		t.Fatalf("Failed to record run: %v", err)
+	}
+	runs, _ = store.List(context.Background(), "failing", 10, 0)
+	if len(runs) != 1 || runs[0].Error != "boom" || runs[0].Tags != nil {
+		t.Errorf("Unexpected failed run: %+v", runs)
+	}
+}
+
+func TestSQLHistoryStoreKeepsTagsWithCommas(t *testing.T) {
+	store, err := NewSQLHistoryStore(newTestSQLiteDB(t), HistoryDriverSQLite)
+	if err != nil {
+		t.Fatalf("Failed to create history store: %v", err)
+	}
+
+	tags := []string{"region:eu,us", "daily", `quoted "tag"`}
+	run := JobRun{JobName: "report", Tags: tags, StartedAt: time.Now(), FinishedAt: time.Now(), Outcome: OutcomeSucceeded, Attempt: 1}
+	if err := store.Record(context.Background(), run); err != nil {
+		t.Fatalf("Failed to record run: %v", err)
+	}
+
+	runs, err := store.List(context.Background(), "report", 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list runs: %v", err)
+	}
+	if len(runs) != 1 || !reflect.DeepEqual(runs[0].Tags, tags) {
+		t.Errorf("Expected tags to round trip, got %+v", runs)
+	}
+}
+
+func TestSQLHistoryStoreRebind(t *testing.T) {
+	postgres := &sqlHistoryStore{driver: HistoryDriverPostgres}
+	if got := postgres.rebind("SELECT ? FROM t WHERE a = ? LIMIT ?"); got != "SELECT $1 FROM t WHERE a = $2 LIMIT $3" {
+		t.Errorf("Unexpected postgres query: %s", got)
+	}
+
+	mysql := &sqlHistoryStore{driver: HistoryDriverMySQL}
+	if got := mysql.rebind("SELECT ?"); got != "SELECT ?" {
+		t.Errorf("Unexpected mysql query: %s", got)
+	}
+}
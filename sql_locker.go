@@ -0,0 +1,343 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"hash/fnv"
+	"net"
+	"time"
+
+	"github.com/go-co-op/gocron"
+)
+
+// SQLLockTable là tên bảng lease được SQL Locker sử dụng với MySQL và SQLite.
+const SQLLockTable = "scheduler_locks"
+
+// sqlLocker triển khai gocron.Locker interface sử dụng database/sql làm backend.
+//
+// Với PostgreSQL, khóa được thực hiện bằng session-level advisory lock trên một connection
+// riêng. Với MySQL và SQLite, khóa là một lease trong bảng SQLLockTable có thời hạn và owner token.
+type sqlLocker struct {
+	db         *sql.DB
+	driver     string
+	options    RedisLockerOptionsTime
+	instanceID string
+	telemetry  *telemetry
+}
+
+// sqlLeaseLock triển khai gocron.Lock cho lease trong bảng SQLLockTable (MySQL, SQLite).
+type sqlLeaseLock struct {
+	*lease
+
+	locker *sqlLocker
+	key    string
+	token  string
+}
+
+// sqlAdvisoryLock triển khai gocron.Lock cho advisory lock của PostgreSQL.
+type sqlAdvisoryLock struct {
+	*lease
+
+	conn *sql.Conn
+	id   int64
+}
+
+// NewSQLLocker tạo một SQL Locker mới để sử dụng với gocron.
+//
+// driver là một trong "postgres", "mysql" hoặc "sqlite". Tùy chọn sử dụng RedisLockerOptions
+// (KeyPrefix, LockDuration, MaxRetries, RetryDelay, InstanceID). Với MySQL và SQLite, bảng
+// SQLLockTable được tự động tạo nếu chưa tồn tại.
+//
+// Example:
+//
+//	db, err := sql.Open("postgres", dsn)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	locker, err := scheduler.NewSQLLocker(db, scheduler.LockDriverPostgres)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithDistributedLocker(locker)
+func NewSQLLocker(db *sql.DB, driver string, opts ...RedisLockerOptions) (gocron.Locker, error) {
+	if db == nil {
+		return nil, ErrSQLDBNil
+	}
+
+	switch driver {
+	case LockDriverPostgres, LockDriverMySQL, LockDriverSQLite:
+	default:
+		return nil, ErrUnsupportedLockDriver
+	}
+
+	// Sử dụng tùy chọn mặc định
+	options := DefaultRedisLockerOptions()
+
+	// Nếu có tùy chọn được cung cấp, sử dụng chúng
+	if len(opts) > 0 {
+		options = opts[0]
+
+		// Validate các giá trị options
+		if err := validateRedisLockerOptions(options); err != nil {
+			return nil, err
+		}
+	}
+
+	timeOptions := options.ToTimeDuration()
+
+	// Kiểm tra kết nối đến database
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, ErrFailedToConnectToSQL
+	}
+
+	locker := &sqlLocker{
+		db:         db,
+		driver:     driver,
+		options:    timeOptions,
+		instanceID: resolveInstanceID(timeOptions.InstanceID),
+	}
+
+	if driver != LockDriverPostgres {
+		if _, err := db.ExecContext(ctx, createLockTableQuery); err != nil {
+			return nil, err
+		}
+	}
+
+	return locker, nil
+}
+
+// setTelemetry triển khai instrumented.
+func (s *sqlLocker) setTelemetry(telemetry *telemetry) {
+	s.telemetry = telemetry
+}
+
+// Lock triển khai phương thức Lock của gocron.Locker interface.
+func (s *sqlLocker) Lock(ctx context.Context, key string) (gocron.Lock, error) {
+	fullKey := s.options.KeyPrefix + key
+	retries := 0
+
+	for {
+		lock, err := s.tryLock(ctx, fullKey)
+		if err != nil {
+			return nil, err
+		}
+		if lock != nil {
+			return lock, nil
+		}
+
+		// Nếu đã thử tối đa số lần
+		if retries >= s.options.MaxRetries {
+			return nil, ErrFailedToAcquireLock
+		}
+
+		// Chờ một khoảng thời gian trước khi thử lại
+		select {
+		case <-time.After(s.options.RetryDelay):
+			retries++
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// tryLock thử lấy khóa một lần, trả về nil nếu khóa đang được giữ bởi owner khác.
+func (s *sqlLocker) tryLock(ctx context.Context, key string) (gocron.Lock, error) {
+	if s.driver == LockDriverPostgres {
+		return s.tryAdvisoryLock(ctx, key)
+	}
+	return s.tryLeaseLock(ctx, key)
+}
+
+// tryLeaseLock lấy lease trong bảng SQLLockTable: chiếm lease đã hết hạn hoặc tạo lease mới.
+func (s *sqlLocker) tryLeaseLock(ctx context.Context, key string) (gocron.Lock, error) {
+	token, err := newLockToken(s.instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.options.LockDuration).UnixMilli()
+
+	// Chiếm lease đã hết hạn
+	result, err := s.db.ExecContext(ctx, takeoverLeaseQuery, token, expiresAt, key, now.UnixMilli())
+	if err != nil {
+		return nil, err
+	}
+	acquired, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	// Tạo lease mới nếu chưa tồn tại
+	if acquired == 0 {
+		insert := insertLeaseQuerySQLite
+		if s.driver == LockDriverMySQL {
+			insert = insertLeaseQueryMySQL
+		}
+		result, err = s.db.ExecContext(ctx, insert, key, token, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		if acquired, err = result.RowsAffected(); err != nil {
+			return nil, err
+		}
+	}
+
+	if acquired == 0 {
+		return nil, nil
+	}
+
+	lock := &sqlLeaseLock{
+		locker: s,
+		key:    key,
+		token:  token,
+	}
+
+	// Bắt đầu quá trình tự động gia hạn khóa
+	lock.lease = newLease(key, s.options.LockDuration, lock.renew, s.telemetry)
+
+	return lock, nil
+}
+
+// tryAdvisoryLock lấy advisory lock của PostgreSQL trên một connection riêng.
+// Khóa được giữ cho tới khi Unlock hoặc khi connection bị đóng.
+func (s *sqlLocker) tryAdvisoryLock(ctx context.Context, key string) (gocron.Lock, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := advisoryLockID(key)
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", id).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if !acquired {
+		_ = conn.Close()
+		return nil, nil
+	}
+
+	lock := &sqlAdvisoryLock{
+		conn: conn,
+		id:   id,
+	}
+
+	// Advisory lock không hết hạn, việc gia hạn chỉ kiểm tra session vẫn còn sống
+	lock.lease = newLease(key, s.options.LockDuration, lock.renew, s.telemetry)
+
+	return lock, nil
+}
+
+// renew gia hạn lease nếu lease vẫn thuộc về owner token hiện tại.
+func (l *sqlLeaseLock) renew(ctx context.Context) error {
+	now := time.Now()
+	expiresAt := now.Add(l.locker.options.LockDuration).UnixMilli()
+
+	result, err := l.locker.db.ExecContext(ctx, renewLeaseQuery, expiresAt, l.key, l.token, now.UnixMilli())
+	if err != nil {
+		return err
+	}
+	renewed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if renewed == 0 {
+		return ErrLockOwnershipLost
+	}
+	return nil
+}
+
+// Unlock triển khai phương thức Unlock của gocron.Lock interface.
+//
+// Lease chỉ bị xóa nếu vẫn thuộc về owner token hiện tại và chưa hết hạn, ngược lại
+// Unlock trả về ErrLockOwnershipLost.
+func (l *sqlLeaseLock) Unlock(ctx context.Context) error {
+	l.stop()
+
+	result, err := l.locker.db.ExecContext(ctx, deleteLeaseQuery, l.key, l.token, time.Now().UnixMilli())
+	if err != nil {
+		return err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrLockOwnershipLost
+	}
+	return nil
+}
+
+// renew kiểm tra session giữ advisory lock vẫn còn kết nối.
+// Khi session bị đóng hoặc connection bị lỗi mạng, PostgreSQL đã (hoặc sẽ) giải phóng khóa
+// và connection không dùng lại được nên khóa được coi là đã mất.
+func (l *sqlAdvisoryLock) renew(ctx context.Context) error {
+	if err := l.conn.PingContext(ctx); err != nil {
+		var netErr net.Error
+		if errors.Is(err, sql.ErrConnDone) || errors.Is(err, driver.ErrBadConn) || errors.As(err, &netErr) {
+			return ErrLockOwnershipLost
+		}
+		return err
+	}
+	return nil
+}
+
+// Unlock giải phóng advisory lock và trả connection về pool.
+func (l *sqlAdvisoryLock) Unlock(ctx context.Context) error {
+	l.stop()
+	defer l.conn.Close()
+
+	var released bool
+	if err := l.conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", l.id).Scan(&released); err != nil {
+		return err
+	}
+	if !released {
+		return ErrLockOwnershipLost
+	}
+	return nil
+}
+
+// advisoryLockID chuyển lock key thành định danh int64 cho advisory lock của PostgreSQL.
+func advisoryLockID(key string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return int64(h.Sum64())
+}
+
+// Các câu lệnh SQL cho lease table (MySQL, SQLite). expires_at lưu Unix milliseconds.
+const (
+	createLockTableQuery = `CREATE TABLE IF NOT EXISTS ` + SQLLockTable + ` (
+	lock_key VARCHAR(255) NOT NULL PRIMARY KEY,
+	owner VARCHAR(255) NOT NULL,
+	expires_at BIGINT NOT NULL
+)`
+
+	takeoverLeaseQuery = `UPDATE ` + SQLLockTable + ` SET owner = ?, expires_at = ? WHERE lock_key = ? AND expires_at <= ?`
+
+	insertLeaseQuerySQLite = `INSERT OR IGNORE INTO ` + SQLLockTable + ` (lock_key, owner, expires_at) VALUES (?, ?, ?)`
+
+	insertLeaseQueryMySQL = `INSERT IGNORE INTO ` + SQLLockTable + ` (lock_key, owner, expires_at) VALUES (?, ?, ?)`
+
+	renewLeaseQuery = `UPDATE ` + SQLLockTable + ` SET expires_at = ? WHERE lock_key = ? AND owner = ? AND expires_at > ?`
+
+	deleteLeaseQuery = `DELETE FROM ` + SQLLockTable + ` WHERE lock_key = ? AND owner = ? AND expires_at > ?`
+)
+
+// Error constants cho SQL Locker
+var (
+	// ErrSQLDBNil được trả về khi *sql.DB nil.
+	ErrSQLDBNil = errors.New("scheduler: sql db is nil")
+
+	// ErrUnsupportedLockDriver được trả về khi driver của locker không được hỗ trợ.
+	ErrUnsupportedLockDriver = errors.New("scheduler: unsupported lock driver")
+
+	// ErrFailedToConnectToSQL được trả về khi không thể kết nối đến database.
+	ErrFailedToConnectToSQL = errors.New("scheduler: failed to connect to sql database")
+)
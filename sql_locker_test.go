@@ -0,0 +1,232 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newTestSQLiteDB mở một database SQLite tạm thời cho test.
+func newTestSQLiteDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "locks.db")+"?_busy_timeout=5000")
+	if err != nil {
+		t.Fatalf("Failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+// testSQLLockerOptions trả về tùy chọn với thời hạn ngắn cho test.
+func testSQLLockerOptions(instanceID string) RedisLockerOptions {
+	return RedisLockerOptions{
+		KeyPrefix:    "scheduler_lock:",
+		LockDuration: 1,
+		MaxRetries:   0,
+		RetryDelay:   10,
+		InstanceID:   instanceID,
+	}
+}
+
+func TestNewSQLLockerValidation(t *testing.T) {
+	if _, err := NewSQLLocker(nil, LockDriverSQLite); err != ErrSQLDBNil {
+		t.Errorf("Expected ErrSQLDBNil, got %v", err)
+	}
+
+	db := newTestSQLiteDB(t)
+	if _, err := NewSQLLocker(db, "oracle"); err != ErrUnsupportedLockDriver {
+		t.Errorf("Expected ErrUnsupportedLockDriver, got %v", err)
+	}
+
+	if _, err := NewSQLLocker(db, LockDriverSQLite, RedisLockerOptions{KeyPrefix: "test:", LockDuration: 0, MaxRetries: 1, RetryDelay: 10}); err != ErrInvalidLockDuration {
+		t.Errorf("Expected ErrInvalidLockDuration, got %v", err)
+	}
+}
+
+func TestSQLLockerLeaseContention(t *testing.T) {
+	db := newTestSQLiteDB(t)
+	nodeA, err := NewSQLLocker(db, LockDriverSQLite, testSQLLockerOptions("node-a"))
+	if err != nil {
+		t.Fatalf("Failed to create sql locker: %v", err)
+	}
+	nodeB, _ := NewSQLLocker(db, LockDriverSQLite, testSQLLockerOptions("node-b"))
+
+	lock, err := nodeA.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	var owner string
+	if err := db.QueryRow("SELECT owner FROM "+SQLLockTable+" WHERE lock_key = ?", "scheduler_lock:job").Scan(&owner); err != nil {
+		t.Fatalf("Failed to read lease: %v", err)
+	}
+	if !strings.HasPrefix(owner, "node-a:") {
+		t.Errorf("Expected lease owner to start with node-a:, got %q", owner)
+	}
+
+	if _, err := nodeB.Lock(context.Background(), "job"); err != ErrFailedToAcquireLock {
+		t.Errorf("Expected ErrFailedToAcquireLock, got %v", err)
+	}
+
+	if err := lock.Unlock(context.Background()); err != nil {
+		t.Fatalf("Failed to unlock: %v", err)
+	}
+
+	lockB, err := nodeB.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Expected node-b to acquire released lock, got %v", err)
+	}
+	_ = lockB.Unlock(context.Background())
+}
+
+func TestSQLLockerTakesOverExpiredLease(t *testing.T) {
+	db := newTestSQLiteDB(t)
+	locker, err := NewSQLLocker(db, LockDriverSQLite, testSQLLockerOptions("node-a"))
+	if err != nil {
+		t.Fatalf("Failed to create sql locker: %v", err)
+	}
+
+	// Lease đã hết hạn của một node đã chết
+	if _, err := db.Exec("INSERT INTO "+SQLLockTable+" (lock_key, owner, expires_at) VALUES (?, ?, ?)",
+		"scheduler_lock:job", "dead-node:token", time.Now().Add(-time.Second).UnixMilli()); err != nil {
+		t.Fatalf("Failed to insert expired lease: %v", err)
+	}
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Expected to take over expired lease, got %v", err)
+	}
+	_ = lock.Unlock(context.Background())
+}
+
+func TestSQLLeaseRenewAndLost(t *testing.T) {
+	db := newTestSQLiteDB(t)
+	locker, _ := NewSQLLocker(db, LockDriverSQLite, testSQLLockerOptions("node-a"))
+
+	lock, err := locker.Lock(context.Background(), "job")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+
+	// Vượt quá LockDuration, lease vẫn phải được giữ nhờ gia hạn
+	time.Sleep(1500 * time.Millisecond)
+
+	var expiresAt int64
+	if err := db.QueryRow("SELECT expires_at FROM "+SQLLockTable+" WHERE lock_key = ?", "scheduler_lock:job").Scan(&expiresAt); err != nil {
+		t.Fatalf("Failed to read lease: %v", err)
+	}
+	if expiresAt <= time.Now().UnixMilli() {
+		t.Fatal("Expected lease to be renewed")
+	}
+
+	// Lease bị node khác chiếm
+	if _, err := db.Exec("UPDATE "+SQLLockTable+" SET owner = ? WHERE lock_key = ?", "node-b:token", "scheduler_lock:job"); err != nil {
+		t.Fatalf("Failed to change lease owner: %v", err)
+	}
+
+	notifier := lock.(LockLostNotifier)
+	select {
+	case <-notifier.Lost():
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected lost signal after takeover")
+	}
+	if !errors.Is(notifier.Err(), ErrLockOwnershipLost) {
+		t.Errorf("Expected ErrLockOwnershipLost, got %v", notifier.Err())
+	}
+
+	if err := lock.Unlock(context.Background()); !errors.Is(err, ErrLockOwnershipLost) {
+		t.Errorf("Expected ErrLockOwnershipLost on unlock, got %v", err)
+	}
+}
+
+func TestAdvisoryLockID(t *testing.T) {
+	if advisoryLockID("scheduler_lock:a") == advisoryLockID("scheduler_lock:b") {
+		t.Error("Expected different advisory lock ids for different keys")
+	}
+	if advisoryLockID("scheduler_lock:a") != advisoryLockID("scheduler_lock:a") {
+		t.Error("Expected stable advisory lock id")
+	}
+}
+
+// brokenConnector tạo connection giả lập, Ping trả về err sau khi connection bị đóng.
+type brokenConnector struct {
+	err error
+}
+
+func (c *brokenConnector) Connect(context.Context) (driver.Conn, error) {
+	return &brokenConn{err: c.err}, nil
+}
+
+func (c *brokenConnector) Driver() driver.Driver { return nil }
+
+type brokenConn struct {
+	err    error
+	closed atomic.Bool
+}
+
+func (c *brokenConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *brokenConn) Close() error                        { c.closed.Store(true); return nil }
+func (c *brokenConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+func (c *brokenConn) Ping(context.Context) error {
+	if c.closed.Load() {
+		return c.err
+	}
+	return nil
+}
+
+func TestSQLAdvisoryLockLostWhenConnectionCloses(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"bad connection", driver.ErrBadConn},
+		{"network error", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := sql.OpenDB(&brokenConnector{err: tt.err})
+			t.Cleanup(func() { _ = db.Close() })
+
+			conn, err := db.Conn(context.Background())
+			if err != nil {
+				t.Fatalf("Failed to open connection: %v", err)
+			}
+			lock := &sqlAdvisoryLock{conn: conn, id: advisoryLockID("job")}
+			lock.lease = newLease("job", 300*time.Millisecond, lock.renew, nil)
+			defer lock.stop()
+
+			// Connection giữ session của advisory lock bị đóng
+			if err := conn.Raw(func(dc any) error {
+				return dc.(driver.Conn).Close()
+			}); err != nil {
+				t.Fatalf("Failed to close connection: %v", err)
+			}
+			if err := lock.renew(context.Background()); !errors.Is(err, ErrLockOwnershipLost) {
+				t.Errorf("Expected renew to report ErrLockOwnershipLost, got %v", err)
+			}
+
+			select {
+			case <-lock.Lost():
+			case <-time.After(time.Second):
+				t.Fatal("Expected lost signal after the connection closed")
+			}
+			if !errors.Is(lock.Err(), ErrLockOwnershipLost) {
+				t.Errorf("Expected ErrLockOwnershipLost, got %v", lock.Err())
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// SQLPauseTable là tên bảng trạng thái tạm dừng được SQL PauseStore sử dụng.
+const SQLPauseTable = "scheduler_pauses"
+
+// Các loại mục tiêu tạm dừng trong SQLPauseTable.
+const (
+	pauseKindJob = "job"
+	pauseKindTag = "tag"
+)
+
+// sqlPauseStore triển khai PauseStore sử dụng database/sql làm backend.
+type sqlPauseStore struct {
+	db     *sql.DB
+	insert string // Câu lệnh thêm mục tiêu tạm dừng theo dialect của driver
+	delete string // Câu lệnh xóa mục tiêu tạm dừng theo dialect của driver
+}
+
+// NewSQLPauseStore tạo PauseStore lưu trạng thái tạm dừng trong bảng SQLPauseTable, dùng chung
+// giữa các instance.
+//
+// driver là một trong "postgres", "mysql" hoặc "sqlite". Bảng SQLPauseTable được tự động tạo
+// nếu chưa tồn tại.
+//
+// Example:
+//
+//	store, err := scheduler.NewSQLPauseStore(db, scheduler.LockDriverPostgres)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	sched.WithPauseStore(store)
+func NewSQLPauseStore(db *sql.DB, driver string) (PauseStore, error) {
+	if db == nil {
+		return nil, ErrSQLDBNil
+	}
+
+	var insert string
+	switch driver {
+	case LockDriverPostgres:
+		insert = insertPauseQueryPostgres
+	case LockDriverMySQL:
+		insert = insertPauseQueryMySQL
+	case LockDriverSQLite:
+		insert = insertPauseQuerySQLite
+	default:
+		return nil, ErrUnsupportedLockDriver
+	}
+
+	// Kiểm tra kết nối đến database
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, ErrFailedToConnectToSQL
+	}
+
+	if _, err := db.ExecContext(ctx, createPauseTableQuery); err != nil {
+		return nil, err
+	}
+
+	return &sqlPauseStore{
+		db:     db,
+		insert: rebindQuery(driver, insert),
+		delete: rebindQuery(driver, deletePauseQuery),
+	}, nil
+}
+
+// SetJobPaused triển khai PauseStore.
+func (s *sqlPauseStore) SetJobPaused(ctx context.Context, name string, paused bool) error {
+	return s.set(ctx, pauseKindJob, name, paused)
+}
+
+// SetTagPaused triển khai PauseStore.
+func (s *sqlPauseStore) SetTagPaused(ctx context.Context, tag string, paused bool) error {
+	return s.set(ctx, pauseKindTag, tag, paused)
+}
+
+// Load triển khai PauseStore.
+func (s *sqlPauseStore) Load(ctx context.Context) (PauseState, error) {
+	rows, err := s.db.QueryContext(ctx, listPausesQuery)
+	if err != nil {
+		return PauseState{}, err
+	}
+	defer rows.Close()
+
+	state := PauseState{Jobs: []string{}, Tags: []string{}}
+	for rows.Next() {
+		var kind, name string
+		if err := rows.Scan(&kind, &name); err != nil {
+			return PauseState{}, err
+		}
+		switch kind {
+		case pauseKindJob:
+			state.Jobs = append(state.Jobs, name)
+		case pauseKindTag:
+			state.Tags = append(state.Tags, name)
+		}
+	}
+	return state, rows.Err()
+}
+
+// set thêm mục tiêu (kind, name) vào bảng nếu paused là true, ngược lại xóa khỏi bảng.
+func (s *sqlPauseStore) set(ctx context.Context, kind, name string, paused bool) error {
+	query := s.delete
+	if paused {
+		query = s.insert
+	}
+	_, err := s.db.ExecContext(ctx, query, kind, name)
+	return err
+}
+
+// Các câu lệnh SQL cho bảng trạng thái tạm dừng.
+const (
+	createPauseTableQuery = `CREATE TABLE IF NOT EXISTS ` + SQLPauseTable + ` (
+	kind VARCHAR(8) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	PRIMARY KEY (kind, name)
+)`
+
+	insertPauseQueryPostgres = `INSERT INTO ` + SQLPauseTable + ` (kind, name) VALUES (?, ?) ON CONFLICT DO NOTHING`
+
+	insertPauseQueryMySQL = `INSERT IGNORE INTO ` + SQLPauseTable + ` (kind, name) VALUES (?, ?)`
+
+	insertPauseQuerySQLite = `INSERT OR IGNORE INTO ` + SQLPauseTable + ` (kind, name) VALUES (?, ?)`
+
+	deletePauseQuery = `DELETE FROM ` + SQLPauseTable + ` WHERE kind = ? AND name = ?`
+
+	listPausesQuery = `SELECT kind, name FROM ` + SQLPauseTable + ` ORDER BY kind, name`
+)
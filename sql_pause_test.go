@@ -0,0 +1,40 @@
+package scheduler
+
+import (
+	"testing"
+)
+
+func TestNewSQLPauseStoreValidation(t *testing.T) {
+	if _, err := NewSQLPauseStore(nil, LockDriverSQLite); err != ErrSQLDBNil {
+		t.Errorf("Expected ErrSQLDBNil, got %v", err)
+	}
+
+	db := newTestSQLiteDB(t)
+	if _, err := NewSQLPauseStore(db, LockDriverRedis); err != ErrUnsupportedLockDriver {
+		t.Errorf("Expected ErrUnsupportedLockDriver, got %v", err)
+	}
+
+	// Bảng đã tồn tại không gây lỗi
+	for i := 0; i < 2; i++ {
+		if _, err := NewSQLPauseStore(db, LockDriverSQLite); err != nil {
+			t.Fatalf("Failed to create pause store: %v", err)
+		}
+	}
+}
+
+func TestSQLPauseStore(t *testing.T) {
+	store, err := NewSQLPauseStore(newTestSQLiteDB(t), LockDriverSQLite)
+	if err != nil {
+		t.Fatalf("Failed to create pause store: %v", err)
+	}
+	testPauseStore(t, store)
+}
+
+func TestSQLPauseStorePostgresQueries(t *testing.T) {
+	if got := rebindQuery(LockDriverPostgres, insertPauseQueryPostgres); got != `INSERT INTO `+SQLPauseTable+` (kind, name) VALUES ($1, $2) ON CONFLICT DO NOTHING` {
+		t.Errorf("Unexpected postgres insert query: %s", got)
+	}
+	if got := rebindQuery(LockDriverMySQL, deletePauseQuery); got != deletePauseQuery {
+		t.Errorf("Expected mysql query to keep ? placeholders, got %s", got)
+	}
+}
@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"log/slog"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// telemetry giữ logger, MetricsCollector và tracer dùng chung giữa Manager và các locker, elector
+// được gắn vào Manager, cho phép thay chúng sau khi locker và elector đã được tạo.
+type telemetry struct {
+	log     atomic.Pointer[slog.Logger]
+	collect atomic.Pointer[metricsCollectorRef]
+	tracing atomic.Pointer[tracerRef]
+}
+
+// metricsCollectorRef bọc MetricsCollector để lưu trong atomic.Pointer.
+type metricsCollectorRef struct {
+	MetricsCollector
+}
+
+// newTelemetry tạo telemetry với logger ban đầu và không thu thập metrics.
+func newTelemetry(logger *slog.Logger) *telemetry {
+	t := &telemetry{}
+	t.setLogger(logger)
+	return t
+}
+
+// logger trả về logger hiện tại. telemetry nil (locker chưa gắn vào Manager) không ghi log.
+func (t *telemetry) logger() *slog.Logger {
+	if t == nil {
+		return discardLogger
+	}
+	if logger := t.log.Load(); logger != nil {
+		return logger
+	}
+	return discardLogger
+}
+
+// setLogger thay logger hiện tại, nil nghĩa là không ghi log.
+func (t *telemetry) setLogger(logger *slog.Logger) {
+	t.log.Store(logger)
+}
+
+// metrics trả về MetricsCollector hiện tại. telemetry nil hoặc chưa có collector không thu thập metrics.
+func (t *telemetry) metrics() MetricsCollector {
+	if t == nil {
+		return noopMetrics{}
+	}
+	if ref := t.collect.Load(); ref != nil {
+		return ref.MetricsCollector
+	}
+	return noopMetrics{}
+}
+
+// setMetrics thay MetricsCollector hiện tại, nil nghĩa là không thu thập metrics.
+func (t *telemetry) setMetrics(collector MetricsCollector) {
+	if collector == nil {
+		t.collect.Store(nil)
+		return
+	}
+	t.collect.Store(&metricsCollectorRef{collector})
+}
+
+// tracerRef bọc trace.Tracer để lưu trong atomic.Pointer.
+type tracerRef struct {
+	trace.Tracer
+}
+
+// noopTracer là tracer không ghi nhận span.
+var noopTracer = noop.NewTracerProvider().Tracer(tracerName)
+
+// tracer trả về tracer hiện tại. telemetry nil hoặc chưa có TracerProvider không ghi nhận span.
+func (t *telemetry) tracer() trace.Tracer {
+	if t == nil {
+		return noopTracer
+	}
+	if ref := t.tracing.Load(); ref != nil {
+		return ref.Tracer
+	}
+	return noopTracer
+}
+
+// setTracerProvider thay tracer hiện tại bằng tracer của provider, nil nghĩa là không ghi nhận span.
+func (t *telemetry) setTracerProvider(provider trace.TracerProvider) {
+	if provider == nil {
+		t.tracing.Store(nil)
+		return
+	}
+	t.tracing.Store(&tracerRef{provider.Tracer(tracerName)})
+}
+
+// instrumented được triển khai bởi các locker và elector trong package để ghi log và metrics
+// qua telemetry của Manager mà chúng được gắn vào.
+type instrumented interface {
+	setTelemetry(telemetry *telemetry)
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTelemetryLogger(t *testing.T) {
+	var nilTelemetry *telemetry
+	if nilTelemetry.logger() != discardLogger {
+		t.Error("Expected nil telemetry to discard logs")
+	}
+
+	logger, buf := newTestLogger()
+	tel := newTelemetry(logger)
+	tel.logger().Info("hello")
+	if !strings.Contains(buf.String(), "hello") {
+		t.Errorf("Expected log to be written, got %q", buf.String())
+	}
+
+	tel.setLogger(nil)
+	if tel.logger() != discardLogger {
+		t.Error("Expected nil logger to discard logs")
+	}
+}
+
+func TestTelemetryMetrics(t *testing.T) {
+	var nilTelemetry *telemetry
+	if _, ok := nilTelemetry.metrics().(noopMetrics); !ok {
+		t.Error("Expected nil telemetry not to collect metrics")
+	}
+
+	tel := newTelemetry(nil)
+	if _, ok := tel.metrics().(noopMetrics); !ok {
+		t.Error("Expected no metrics collector by default")
+	}
+
+	collector := &recordingMetrics{}
+	tel.setMetrics(collector)
+	if tel.metrics() != collector {
+		t.Error("Expected configured metrics collector")
+	}
+
+	tel.setMetrics(nil)
+	if _, ok := tel.metrics().(noopMetrics); !ok {
+		t.Error("Expected nil collector to disable metrics")
+	}
+}
@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName là tên instrumentation scope của các span do scheduler tạo.
+const tracerName = "go.fork.vn/scheduler"
+
+// Tên các span do scheduler tạo.
+const (
+	// SpanJobRun là tên span của mỗi lần chạy job (tính cả các lần thử lại)
+	SpanJobRun = "scheduler.job"
+
+	// SpanLockAcquire là tên span con của SpanJobRun cho việc lấy khóa phân tán
+	SpanLockAcquire = "scheduler.lock"
+)
+
+// Các thuộc tính của span do scheduler tạo.
+const (
+	// AttrJobName là tên của job
+	AttrJobName = attribute.Key("scheduler.job.name")
+
+	// AttrJobTags là các tag của job
+	AttrJobTags = attribute.Key("scheduler.job.tags")
+
+	// AttrJobSchedule mô tả lịch chạy của job, ví dụ "every 5 seconds" hoặc "cron */5 * * * *"
+	AttrJobSchedule = attribute.Key("scheduler.job.schedule")
+
+	// AttrJobAttempt là số thứ tự của lần thử cuối cùng, bắt đầu từ 1
+	AttrJobAttempt = attribute.Key("scheduler.job.attempt")
+
+	// AttrJobOutcome là kết quả của lần chạy: succeeded, failed, timeout hoặc skipped
+	AttrJobOutcome = attribute.Key("scheduler.job.outcome")
+
+	// AttrLockKey là key của khóa phân tán
+	AttrLockKey = attribute.Key("scheduler.lock.key")
+
+	// AttrLockAcquired cho biết khóa phân tán có được lấy thành công hay không
+	AttrLockAcquired = attribute.Key("scheduler.lock.acquired")
+)
+
+// Các giá trị của AttrJobOutcome và JobRun.Outcome.
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeFailed    = "failed"
+	OutcomeTimeout   = "timeout"
+	OutcomeSkipped   = "skipped"
+)
+
+// lockAcquisition ghi nhận thời điểm bắt đầu và kết thúc lấy khóa phân tán của một lần chạy,
+// dùng để tạo span lấy khóa làm con của span lần chạy được tạo sau đó.
+type lockAcquisition struct {
+	start time.Time
+	end   time.Time
+}
+
+// startJobSpan tạo span cho lần chạy của job. Nếu khóa phân tán của job vừa được lấy,
+// span bắt đầu từ lúc lấy khóa và có span con SpanLockAcquire.
+func (m *manager) startJobSpan(ctx context.Context, def *jobDefinition) (context.Context, trace.Span) {
+	tracer := m.telemetry.tracer()
+
+	attrs := []attribute.KeyValue{AttrJobName.String(def.name)}
+	if len(def.tags) > 0 {
+		attrs = append(attrs, AttrJobTags.StringSlice(def.tags))
+	}
+	if def.schedule != "" {
+		attrs = append(attrs, AttrJobSchedule.String(def.schedule))
+	}
+	opts := []trace.SpanStartOption{trace.WithAttributes(attrs...)}
+
+	acquisition, locked := m.locks.takeAcquisition(def.name)
+	if locked {
+		opts = append(opts, trace.WithTimestamp(acquisition.start), trace.WithAttributes(AttrLockKey.String(def.name)))
+	}
+
+	ctx, span := tracer.Start(ctx, SpanJobRun, opts...)
+	if locked {
+		traceLockAcquire(ctx, tracer, def.name, acquisition, true, nil)
+	}
+	return ctx, span
+}
+
+// endJobSpan ghi nhận kết quả của lần chạy vào span và kết thúc span.
+func endJobSpan(span trace.Span, err error) {
+	span.SetAttributes(AttrJobOutcome.String(runOutcome(err)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// traceSkippedRun ghi nhận lần chạy bị bỏ qua vì không lấy được khóa phân tán cho key.
+// err là lỗi của locker, nil hoặc ErrFailedToAcquireLock nếu khóa đang được giữ bởi instance khác.
+func traceSkippedRun(tracer trace.Tracer, key string, acquisition lockAcquisition, err error) {
+	ctx, span := tracer.Start(context.Background(), SpanJobRun,
+		trace.WithTimestamp(acquisition.start),
+		trace.WithAttributes(AttrJobName.String(key), AttrLockKey.String(key), AttrJobOutcome.String(OutcomeSkipped)),
+	)
+	traceLockAcquire(ctx, tracer, key, acquisition, false, err)
+	span.End(trace.WithTimestamp(acquisition.end))
+}
+
+// traceLockAcquire tạo span SpanLockAcquire đã kết thúc cho lần lấy khóa phân tán.
+func traceLockAcquire(ctx context.Context, tracer trace.Tracer, key string, acquisition lockAcquisition, acquired bool, err error) {
+	_, span := tracer.Start(ctx, SpanLockAcquire,
+		trace.WithTimestamp(acquisition.start),
+		trace.WithAttributes(AttrLockKey.String(key), AttrLockAcquired.Bool(acquired)),
+	)
+	if err != nil && !errors.Is(err, ErrFailedToAcquireLock) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End(trace.WithTimestamp(acquisition.end))
+}
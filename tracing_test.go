@@ -0,0 +1,265 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// newTestTracerProvider tạo TracerProvider ghi span vào exporter trong bộ nhớ.
+func newTestTracerProvider() (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	return sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), exporter
+}
+
+// findSpan trả về span đầu tiên có tên name, nil nếu không có.
+func findSpan(spans tracetest.SpanStubs, name string) *tracetest.SpanStub {
+	for i := range spans {
+		if spans[i].Name == name {
+			return &spans[i]
+		}
+	}
+	return nil
+}
+
+// spanAttr trả về giá trị của thuộc tính key trong span.
+func spanAttr(span *tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+// waitForSpan chờ tới khi exporter có span tên name.
+func waitForSpan(t *testing.T, exporter *tracetest.InMemoryExporter, name string) *tracetest.SpanStub {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if span := findSpan(exporter.GetSpans(), name); span != nil {
+			return span
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Expected span %s to be exported", name)
+	return nil
+}
+
+func TestManagerTracesJobRun(t *testing.T) {
+	locker, err := NewMemoryLocker(NewMemoryLockStore())
+	if err != nil {
+		t.Fatalf("Failed to create locker: %v", err)
+	}
+
+	provider, exporter := newTestTracerProvider()
+	scheduler := NewScheduler().WithTracerProvider(provider).WithDistributedLocker(locker)
+
+	attempts := 0
+	_, err = scheduler.Every(1).Hours().Tag("reports").Name("traced").
+		Retry(RetryPolicy{MaxAttempts: 2, InitialDelay: time.Millisecond}).
+		DoContext(func(ctx context.Context) error {
+			attempts++
+			_, span := provider.Tracer("test").Start(ctx, "db.query")
+			span.End()
+			if attempts == 1 {
+				return errors.New("first attempt fails")
+			}
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	job := waitForSpan(t, exporter, SpanJobRun)
+	scheduler.Stop()
+
+	if job.Parent.IsValid() {
+		t.Errorf("Expected job span to be a root span")
+	}
+	expected := map[attribute.Key]attribute.Value{
+		AttrJobName:     attribute.StringValue("traced"),
+		AttrJobTags:     attribute.StringSliceValue([]string{"reports"}),
+		AttrJobSchedule: attribute.StringValue("every 1 hours"),
+		AttrJobAttempt:  attribute.IntValue(2),
+		AttrJobOutcome:  attribute.StringValue(OutcomeSucceeded),
+		AttrLockKey:     attribute.StringValue("traced"),
+	}
+	for key, want := range expected {
+		if got, ok := spanAttr(job, key); !ok || got.Emit() != want.Emit() {
+			t.Errorf("Expected %s = %s, got %s", key, want.Emit(), got.Emit())
+		}
+	}
+	if len(job.Events) != 1 || job.Events[0].Name != "scheduler.job.retry" {
+		t.Errorf("Expected one retry event, got %v", job.Events)
+	}
+
+	spans := exporter.GetSpans()
+	lock := findSpan(spans, SpanLockAcquire)
+	if lock == nil {
+		t.Fatal("Expected lock span to be exported")
+	}
+	if lock.Parent.SpanID() != job.SpanContext.SpanID() {
+		t.Errorf("Expected lock span to be a child of the job span")
+	}
+	if acquired, _ := spanAttr(lock, AttrLockAcquired); !acquired.AsBool() {
+		t.Errorf("Expected lock span to record the acquisition")
+	}
+	if lock.StartTime.Before(job.StartTime) || lock.EndTime.After(job.EndTime) {
+		t.Errorf("Expected lock span within the job span")
+	}
+
+	var queries int
+	for _, span := range spans {
+		if span.Name == "db.query" {
+			queries++
+			if span.Parent.SpanID() != job.SpanContext.SpanID() {
+				t.Errorf("Expected spans of the job function to join the job trace")
+			}
+		}
+	}
+	if queries != 2 {
+		t.Errorf("Expected 2 db.query spans, got %d", queries)
+	}
+}
+
+func TestManagerTracesSkippedRun(t *testing.T) {
+	store := NewMemoryLockStore()
+	locker, err := NewMemoryLocker(store)
+	if err != nil {
+		t.Fatalf("Failed to create locker: %v", err)
+	}
+
+	// Khóa của job đang được giữ bởi instance khác
+	other, _ := NewMemoryLocker(store)
+	held, err := other.Lock(context.Background(), "held")
+	if err != nil {
+		t.Fatalf("Failed to acquire lock: %v", err)
+	}
+	defer held.Unlock(context.Background())
+
+	provider, exporter := newTestTracerProvider()
+	scheduler := NewScheduler().WithTracerProvider(provider).WithDistributedLocker(locker)
+	if _, err := scheduler.Every(1).Hours().Name("held").DoContext(func(ctx context.Context) error { return nil }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	job := waitForSpan(t, exporter, SpanJobRun)
+	if outcome, _ := spanAttr(job, AttrJobOutcome); outcome.AsString() != OutcomeSkipped {
+		t.Errorf("Expected outcome %s, got %s", OutcomeSkipped, outcome.AsString())
+	}
+
+	lock := findSpan(exporter.GetSpans(), SpanLockAcquire)
+	if lock == nil {
+		t.Fatal("Expected lock span to be exported")
+	}
+	if lock.Parent.SpanID() != job.SpanContext.SpanID() {
+		t.Errorf("Expected lock span to be a child of the job span")
+	}
+	if acquired, _ := spanAttr(lock, AttrLockAcquired); acquired.AsBool() {
+		t.Errorf("Expected lock span to record the failed acquisition")
+	}
+	if lock.Status.Code == codes.Error {
+		t.Errorf("Expected a held lock not to be reported as an error")
+	}
+}
+
+func TestManagerTracesFailedAndTimedOutRuns(t *testing.T) {
+	provider, exporter := newTestTracerProvider()
+	scheduler := NewScheduler().WithTracerProvider(provider)
+
+	errFailed := errors.New("failed")
+	if _, err := scheduler.Every(1).Hours().Name("failing").DoContext(func(ctx context.Context) error { return errFailed }); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+	_, err := scheduler.Every(1).Hours().Name("slow").Timeout(20 * time.Millisecond).DoContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && len(exporter.GetSpans()) < 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	outcomes := make(map[string]string)
+	for _, span := range exporter.GetSpans() {
+		name, _ := spanAttr(&span, AttrJobName)
+		outcome, _ := spanAttr(&span, AttrJobOutcome)
+		outcomes[name.AsString()] = outcome.AsString()
+		if span.Status.Code != codes.Error {
+			t.Errorf("Expected span of %s to have error status", name.AsString())
+		}
+		if _, ok := spanAttr(&span, AttrLockKey); ok {
+			t.Errorf("Expected no lock key without a distributed locker")
+		}
+	}
+	if outcomes["failing"] != OutcomeFailed || outcomes["slow"] != OutcomeTimeout {
+		t.Errorf("Unexpected outcomes: %v", outcomes)
+	}
+}
+
+func TestJobScheduleDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule func(m Manager)
+		expected string
+	}{
+		{"interval", func(m Manager) { m.Every(5).Minutes() }, "every 5 minutes"},
+		{"duration", func(m Manager) { m.Every(30 * time.Second) }, "every 30s"},
+		{"daily at", func(m Manager) { m.Every(1).Days().At("10:30") }, "every 1 days at 10:30"},
+		{"cron", func(m Manager) { m.Cron("*/5 * * * *") }, "cron */5 * * * *"},
+		{"cron with seconds", func(m Manager) { m.CronWithSeconds("*/10 * * * * *") }, "cron */10 * * * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewScheduler().(*manager)
+			tt.schedule(m)
+			if m.pending.schedule != tt.expected {
+				t.Errorf("Expected schedule %q, got %q", tt.expected, m.pending.schedule)
+			}
+		})
+	}
+}
+
+func TestManagerWithoutTracerProvider(t *testing.T) {
+	scheduler := NewScheduler()
+
+	valid := make(chan bool, 1)
+	if _, err := scheduler.Every(1).Hours().DoContext(func(ctx context.Context) error {
+		valid <- trace.SpanContextFromContext(ctx).IsValid()
+		return nil
+	}); err != nil {
+		t.Fatalf("Failed to schedule job: %v", err)
+	}
+
+	scheduler.StartAsync()
+	defer scheduler.Stop()
+
+	select {
+	case ok := <-valid:
+		if ok {
+			t.Error("Expected no span without a TracerProvider")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected job to run")
+	}
+}